@@ -0,0 +1,357 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokens of an apk version, in the order they may follow each other
+const (
+	apkInvalid = iota - 1
+	apkDigitOrZero
+	apkDigit
+	apkLetter
+	apkSuffix
+	apkSuffixNumber
+	apkRevision
+	apkEnd
+)
+
+var apkPreSuffixes = []string{"alpha", "beta", "pre", "rc"}
+
+var apkPostSuffixes = []string{"cvs", "svn", "git", "hg", "p"}
+
+// APKVersion is a version of an Alpine package, like 1.2.3_rc1-r0
+//
+// Versions are numbers, an optional letter, suffixes and a -r package
+// release, ordered like apk-tools does: _alpha < _beta < _pre < _rc < release
+// < _cvs < _svn < _git < _hg < _p.
+type APKVersion struct {
+	original string
+}
+
+// APK is the scheme of Alpine packages, registered as "apk"
+//
+// Constraints are comma separated comparisons using the operators <, <=, =,
+// >= and >.
+var APK Scheme = apkScheme{}
+
+var apkOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+}
+
+func init() {
+	RegisterScheme("apk", APK)
+}
+
+// Parses an Alpine package version
+//
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseAPK("1.2.3_rc1-r2")
+//     v.Release()
+//     Returns: 2
+func ParseAPK(version string) (APKVersion, error) {
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return APKVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if !isDigit(trimmed[0]) {
+		return APKVersion{}, &ParseError{version, ErrInvalidVersion, "not starting with a number"}
+	}
+
+	tokenizer := &apkTokenizer{trimmed, apkDigit}
+	for tokenizer.kind != apkEnd && tokenizer.kind != apkInvalid {
+		tokenizer.token()
+	}
+
+	if tokenizer.kind == apkInvalid {
+		return APKVersion{}, &ParseError{version, ErrInvalidVersion, ""}
+	}
+
+	return APKVersion{version}, nil
+}
+
+// Compares two Alpine package versions, like apk version -t
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareAPK("1.2.3_rc1-r0", "1.2.3-r0")
+//     Returns: -1
+//
+//     version.CompareAPK("1.2.3_p1", "1.2.3-r5")
+//     Returns: 1
+func CompareAPK(version1, version2 string) int {
+	v1, err1 := ParseAPK(version1)
+	v2, err2 := ParseAPK(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the string given to ParseAPK
+func (self APKVersion) Original() string {
+	return self.original
+}
+
+// Return the version, without the package release
+func (self APKVersion) Version() string {
+	version, _ := self.split()
+
+	return version
+}
+
+// Return the package release, 0 when not given
+func (self APKVersion) Release() int {
+	_, release := self.split()
+	number, _ := strconv.Atoi(release)
+
+	return number
+}
+
+func (self APKVersion) split() (version, release string) {
+	version = strings.TrimSpace(self.original)
+	if i := strings.LastIndex(version, "-r"); i >= 0 {
+		return version[:i], version[i+2:]
+	}
+
+	return version, ""
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self APKVersion) Compare(other APKVersion) int {
+	return apkvercmp(strings.TrimSpace(self.original), strings.TrimSpace(other.original))
+}
+
+// Return true if both versions are equal
+func (self APKVersion) Equal(other APKVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self APKVersion) Less(other APKVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version
+func (self APKVersion) String() string {
+	return strings.TrimSpace(self.original)
+}
+
+// Compares versions like apk_version_compare
+//
+// Versions are read token by token, comparing their values while both are of
+// the same kind. Numbers after a dot with leading zeros are compared as
+// fractions, and a version going on past the other is newer, unless it goes
+// on with a pre-release suffix.
+func apkvercmp(a, b string) int {
+	tokenizer1, tokenizer2 := &apkTokenizer{a, apkDigit}, &apkTokenizer{b, apkDigit}
+
+	var value1, value2 int64
+	for tokenizer1.kind == tokenizer2.kind && tokenizer1.kind != apkEnd && tokenizer1.kind != apkInvalid && value1 == value2 {
+		value1 = tokenizer1.token()
+		value2 = tokenizer2.token()
+	}
+
+	switch {
+	case value1 < value2:
+		return -1
+	case value1 > value2:
+		return 1
+	case tokenizer1.kind == tokenizer2.kind:
+		return 0
+	}
+
+	// a pre-release suffix is older than the end of the version
+	if tokenizer1.kind == apkSuffix && tokenizer1.peek() < 0 {
+		return -1
+	}
+
+	if tokenizer2.kind == apkSuffix && tokenizer2.peek() < 0 {
+		return 1
+	}
+
+	return compareInt(tokenizer2.kind, tokenizer1.kind)
+}
+
+// reads an apk version, kind being the kind of the next token
+type apkTokenizer struct {
+	version string
+	kind    int
+}
+
+// the value of the next token, without consuming it
+func (self apkTokenizer) peek() int64 {
+	return self.token()
+}
+
+// consumes the next token, returning its value and moving to the kind of the
+// following one
+func (self *apkTokenizer) token() int64 {
+	if self.version == "" {
+		// stricter than apk-tools, 1. and 1-r ending with a separator
+		if self.kind == apkDigitOrZero || self.kind == apkSuffix || self.kind == apkRevision {
+			self.kind = apkInvalid
+			return -1
+		}
+
+		self.kind = apkEnd
+		return 0
+	}
+
+	var value int64
+	i := 0
+	next := apkInvalid
+
+	switch self.kind {
+	case apkDigitOrZero:
+		// leading zeros are counted, 1.01 being lower than 1.1
+		if self.version[0] == '0' {
+			for i < len(self.version) && self.version[i] == '0' {
+				i++
+			}
+			value = int64(-i)
+			next = apkDigit
+			break
+		}
+		fallthrough
+	case apkDigit, apkSuffixNumber, apkRevision:
+		for i < len(self.version) && isDigit(self.version[i]) {
+			value = value*10 + int64(self.version[i]-'0')
+			i++
+		}
+
+		// stricter than apk-tools, 1..2 and 1-r1.0 having empty numbers
+		if i == 0 && self.kind != apkDigit {
+			self.kind = apkInvalid
+			return -1
+		}
+	case apkLetter:
+		value = int64(self.version[0])
+		i = 1
+	case apkSuffix:
+		var ok bool
+		if value, i, ok = apkSuffixValue(self.version); !ok {
+			self.kind = apkInvalid
+			return -1
+		}
+	default:
+		self.kind = apkInvalid
+		return -1
+	}
+
+	self.version = self.version[i:]
+
+	switch {
+	case self.version == "":
+		self.kind = apkEnd
+	case next != apkInvalid:
+		self.kind = next
+	default:
+		self.next()
+	}
+
+	return value
+}
+
+// the value and length of a suffix, pre-release ones having negative values
+func apkSuffixValue(version string) (int64, int, bool) {
+	for i, suffix := range apkPreSuffixes {
+		if strings.HasPrefix(version, suffix) {
+			return int64(i - len(apkPreSuffixes)), len(suffix), true
+		}
+	}
+
+	for i, suffix := range apkPostSuffixes {
+		if strings.HasPrefix(version, suffix) {
+			return int64(i), len(suffix), true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// finds the kind of the next token from its first character
+func (self *apkTokenizer) next() {
+	char := self.version[0]
+	kind := apkInvalid
+
+	switch {
+	case (self.kind == apkDigit || self.kind == apkDigitOrZero) && char >= 'a' && char <= 'z':
+		kind = apkLetter
+	case self.kind == apkLetter && isDigit(char):
+		kind = apkDigit
+	case self.kind == apkSuffix && isDigit(char):
+		kind = apkSuffixNumber
+	default:
+		switch {
+		case char == '.':
+			kind = apkDigitOrZero
+		case char == '_':
+			kind = apkSuffix
+		case char == '-' && strings.HasPrefix(self.version, "-r"):
+			kind = apkRevision
+			self.version = self.version[1:]
+		}
+		self.version = self.version[1:]
+	}
+
+	// tokens may only come back to an earlier kind for a new number or suffix
+	if kind < self.kind &&
+		!(kind == apkDigitOrZero && self.kind == apkDigit) &&
+		!(kind == apkSuffix && self.kind == apkSuffixNumber) &&
+		!(kind == apkDigit && self.kind == apkLetter) {
+		kind = apkInvalid
+	}
+
+	self.kind = kind
+}
+
+type apkScheme struct{}
+
+func (apkScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseAPK(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (apkScheme) Compare(version1, version2 string) int {
+	return CompareAPK(version1, version2)
+}
+
+func (apkScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(APKVersion).Compare(v2.(APKVersion))
+}
+
+func (apkScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := parseRelations(APK, constraint, apkOperators)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (apkScheme) Format(version string) (string, error) {
+	v, err := ParseAPK(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
@@ -0,0 +1,107 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var apkValues = map[string][2]interface{}{
+	"1.2.3":          {"1.2.3", 0},
+	"1.2.3_rc1-r2":   {"1.2.3_rc1", 2},
+	"1.2.3a_p1-r10":  {"1.2.3a_p1", 10},
+	"20230101":       {"20230101", 0},
+	"1.0_alpha_pre2": {"1.0_alpha_pre2", 0},
+	" 2.0-r0 ":       {"2.0", 0},
+}
+
+func TestParseAPK(t *testing.T) {
+	for in, out := range apkValues {
+		v, err := ParseAPK(in)
+		if x := [2]interface{}{v.Version(), v.Release()}; err != nil || x != out || v.Original() != in {
+			t.Errorf("FAIL: ParseAPK(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"a1", "1.2.3_foo", "1.2.3-1", "1.2.3-r", "1.", "1.2_", "1.2.3_rc1a", "1.2A", "1..2", "1.2_rc-r1.0", "1-r1_p1"} {
+		if v, err := ParseAPK(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseAPK(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// as given by apk version -t
+var compareAPKValues = map[string]int{
+	"1.0|1.0":                  0,
+	"1.0|1.1":                  -1,
+	"1.0|1":                    1,
+	"1.10|1.9":                 1,
+	"1.01|1.1":                 -1,
+	"1.001|1.01":               -1,
+	"1.0|1.00":                 1,
+	"1.2.3_rc1-r0|1.2.3-r0":    -1,
+	"1.2.3_rc1|1.2.3_rc2":      -1,
+	"1.2.3_alpha|1.2.3_beta":   -1,
+	"1.2.3_beta|1.2.3_pre":     -1,
+	"1.2.3_pre|1.2.3_rc":       -1,
+	"1.2.3_rc|1.2.3":           -1,
+	"1.2.3|1.2.3_cvs":          -1,
+	"1.2.3_cvs|1.2.3_svn":      -1,
+	"1.2.3_git|1.2.3_hg":       -1,
+	"1.2.3_hg|1.2.3_p":         -1,
+	"1.2.3_p1|1.2.3-r5":        1,
+	"1.2.3_p1|1.2.3_p1-r1":     -1,
+	"1.2.3-r1|1.2.3-r10":       -1,
+	"1.2.3-r1|1.2.3.1":         -1,
+	"1.2.3a|1.2.3":             1,
+	"1.2.3a|1.2.3b":            -1,
+	"1.2.3a|1.2.4":             -1,
+	"1.2.3_rc1|1.2.3a":         -1,
+	"1.0_alpha1|1.0_alpha":     1,
+	"1.0_alpha_p1|1.0_alpha":   1,
+	"1.0_alpha_beta|1.0_alpha": -1,
+	"x|1.0":                    -1,
+}
+
+func TestCompareAPK(t *testing.T) {
+	for in, out := range compareAPKValues {
+		v := strings.Split(in, "|")
+		if x := CompareAPK(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareAPK(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareAPK(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareAPK(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := APK.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: APK.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestSortWithAPK(t *testing.T) {
+	versions := []string{"1.2.3-r0", "1.2.3_p1", "1.2.3_rc1-r1", "1.2.3_alpha", "1.2.2", "1.2.3-r1"}
+	SortWith(APK, versions)
+
+	if x := strings.Join(versions, " "); x != "1.2.2 1.2.3_alpha 1.2.3_rc1-r1 1.2.3-r0 1.2.3-r1 1.2.3_p1" {
+		t.Errorf("FAIL: SortWith(APK) = %v", x)
+	}
+}
+
+func TestParseAPKConstraint(t *testing.T) {
+	c, err := APK.ParseConstraint(">= 1.2.3_rc1, < 1.2.3_p1")
+	if err != nil {
+		t.Fatalf("FAIL: APK.ParseConstraint error = %v", err)
+	}
+
+	for in, out := range map[string]bool{"1.2.3": true, "1.2.3-r4": true, "1.2.3_beta": false, "1.2.3_p1": false, "garbage": false, "": false} {
+		if x := c.Match(in); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	if c, err := APK.ParseConstraint(">= 1.2_foo"); c != nil || !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("FAIL: APK.ParseConstraint(>= 1.2_foo) = %v, %v: want error %v", c, err, ErrInvalidVersion)
+	}
+}
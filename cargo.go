@@ -0,0 +1,202 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cargo is the scheme of Rust crates, registered as "cargo"
+//
+// Versions are Semantic Versioning 2.0.0 versions and constraints are Cargo
+// version requirements.
+var Cargo Scheme = cargoScheme{}
+
+func init() {
+	RegisterScheme("cargo", Cargo)
+}
+
+var cargoOperators = []string{"<=", ">=", "<", ">", "=", "~", "^"}
+
+// Parses a Cargo version requirement, like ^1.2.3, ~1.2 or >= 1.2, < 1.5
+//
+// Requirements are comma separated comparators, all of them having to match.
+// A version without operator is a caret requirement, 1.2.3 meaning >=1.2.3
+// <2.0.0, where the left-most non-zero number may not change: ^0.2.3 means
+// >=0.2.3 <0.3.0. Wildcards, like * or 1.2.*, match any number. Like Cargo, a
+// pre-release only matches when a comparator names a pre-release of the same
+// major, minor and patch.
+//
+// The requirement is returned as a ConstraintGroup of plain comparators.
+// Malformed requirements are reported as a *ConstraintError.
+//
+// Usage
+//     c, _ := version.ParseCargoRequirement("0.2.3")
+//     c.Match("0.3.0")
+//     Returns: false
+//
+//     c, _ = version.ParseCargoRequirement(">=1.2, <1.5")
+//     c.Match("1.4.9")
+//     Returns: true
+func ParseCargoRequirement(requirement string) (*ConstraintGroup, error) {
+	group := &ConstraintGroup{scheme: Cargo, admit: admitCargo, admitted: admittedCargo}
+
+	start := 0
+	for _, comparator := range strings.Split(requirement, ",") {
+		offset := start + len(comparator) - len(strings.TrimLeft(comparator, " \t"))
+		start += len(comparator) + 1
+
+		comparator = strings.TrimSpace(comparator)
+		if comparator == "" {
+			return nil, &ConstraintError{requirement, comparator, offset, ErrEmptyConstraint}
+		}
+
+		constraints, err := parseCargoComparator(comparator)
+		if err != nil {
+			return nil, &ConstraintError{requirement, comparator, offset, err}
+		}
+
+		group.AddConstraint(constraints...)
+	}
+
+	return group, nil
+}
+
+// desugars a comparator into plain comparators
+func parseCargoComparator(comparator string) ([]*Constraint, error) {
+	operator := ""
+	for _, candidate := range cargoOperators {
+		if strings.HasPrefix(comparator, candidate) {
+			operator = candidate
+			break
+		}
+	}
+
+	version := strings.TrimLeft(comparator[len(operator):], " \t")
+	if version == "*" && operator == "" {
+		return []*Constraint{}, nil
+	}
+
+	if version == "" || strings.ContainsAny(version, "+ \t") || !isDigit(version[0]) {
+		return nil, ErrInvalidConstraint
+	}
+
+	// wildcards may only end the version, like 1.2.*
+	wildcard := false
+	core, _, _ := strings.Cut(version, "-")
+	for _, segment := range strings.Split(core, ".") {
+		if segment == "*" || segment == "x" || segment == "X" {
+			wildcard = true
+		} else if wildcard {
+			return nil, ErrInvalidConstraint
+		}
+	}
+
+	partial, err := parseNPMPartial(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if operator == "" {
+		operator = "^"
+		if wildcard {
+			operator = "="
+		}
+	}
+
+	low := partial.floor().String()
+
+	// the right-most given number
+	position := 2 - partial.wildcards
+
+	switch operator {
+	case "^":
+		// the left-most non-zero number may not change
+		switch {
+		case partial.numbers[0] != 0 || partial.wildcards == 2:
+			position = 0
+		case partial.numbers[1] != 0 || partial.wildcards == 1:
+			position = 1
+		default:
+			position = 2
+		}
+
+		return []*Constraint{{">=", low}, {"<", partial.bump(position).String()}}, nil
+	case "~":
+		if position > 1 {
+			position = 1
+		}
+
+		return []*Constraint{{">=", low}, {"<", partial.bump(position).String()}}, nil
+	}
+
+	if partial.wildcards == 0 {
+		return []*Constraint{{operator, partial.String()}}, nil
+	}
+
+	switch operator {
+	case ">":
+		return []*Constraint{{">=", partial.bump(position).String()}}, nil
+	case ">=":
+		return []*Constraint{{">=", low}}, nil
+	case "<":
+		return []*Constraint{{"<", low}}, nil
+	case "<=":
+		return []*Constraint{{"<", partial.bump(position).String()}}, nil
+	}
+
+	return []*Constraint{{">=", low}, {"<", partial.bump(position).String()}}, nil
+}
+
+func admitCargo(version string, constraints []*Constraint) bool {
+	return admitPrerelease(ParseSemVer, version, constraints)
+}
+
+func admittedCargo(constraints []*Constraint) *Range {
+	return admittedPrereleases(Cargo, ParseSemVer, constraints)
+}
+
+type cargoScheme struct{}
+
+func (cargoScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseSemVer(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (cargoScheme) Compare(version1, version2 string) int {
+	v1, err1 := ParseSemVer(version1)
+	v2, err2 := ParseSemVer(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+func (cargoScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(SemVer).Compare(v2.(SemVer))
+}
+
+func (cargoScheme) releaseOf(version string) (string, bool) {
+	v, err := ParseSemVer(version)
+
+	return v.release(), err == nil && len(v.prerelease) > 0
+}
+
+func (cargoScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseCargoRequirement(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (cargoScheme) Format(version string) (string, error) {
+	v, err := ParseSemVer(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
@@ -0,0 +1,103 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// as matched by the semver crate
+var cargoRequirements = map[string]bool{
+	"1.2.3|1.2.3":                 true,
+	"1.2.3|1.9.0":                 true,
+	"1.2.3|2.0.0":                 false,
+	"1.2.3|1.2.2":                 false,
+	"^1.2.3|1.5.0":                true,
+	"^0.2.3|0.2.9":                true,
+	"^0.2.3|0.3.0":                false,
+	"^0.0.3|0.0.3":                true,
+	"^0.0.3|0.0.4":                false,
+	"^0.0|0.0.9":                  true,
+	"^0.0|0.1.0":                  false,
+	"^0|0.9.9":                    true,
+	"^0|1.0.0":                    false,
+	"1.2|1.9.9":                   true,
+	"0.2|0.2.9":                   true,
+	"0.2|0.3.0":                   false,
+	"~1.2.3|1.2.9":                true,
+	"~1.2.3|1.3.0":                false,
+	"~1.2|1.2.0":                  true,
+	"~1.2|1.3.0":                  false,
+	"~1|1.9.0":                    true,
+	"~1|2.0.0":                    false,
+	"=1.2.3|1.2.3":                true,
+	"=1.2.3|1.2.4":                false,
+	"=1.2|1.2.9":                  true,
+	"=1.2|1.3.0":                  false,
+	"*|0.0.1":                     true,
+	"*|1.0.0-alpha":               false,
+	"1.*|1.9.0":                   true,
+	"1.*|2.0.0":                   false,
+	"1.2.*|1.2.9":                 true,
+	"1.2.*|1.3.0":                 false,
+	"1.2.x|1.2.1":                 true,
+	">1.2|1.2.9":                  false,
+	">1.2|1.3.0":                  true,
+	">1.2.3|1.2.4":                true,
+	">=1.2|1.2.0":                 true,
+	"<1.2|1.1.9":                  true,
+	"<1.2|1.2.0":                  false,
+	"<=1.2|1.2.9":                 true,
+	"<=1.2|1.3.0":                 false,
+	">= 1.2, < 1.5|1.4.9":         true,
+	">= 1.2, < 1.5|1.5.0":         false,
+	">=1.2.3, <1.8.0|1.8.0-beta":  false,
+	"1.2.3-alpha.1|1.2.3-alpha.2": true,
+	"1.2.3-alpha.1|1.2.3":         true,
+	"1.2.3-alpha.1|1.2.4-alpha":   false,
+	"1.2.3-alpha.1|1.2.3-alpha":   false,
+	"=1.2.3-rc.1|1.2.3-rc.1":      true,
+	"1.2.3|invalid":               false,
+	"1.2.3|v1.2.3":                false,
+}
+
+func TestParseCargoRequirement(t *testing.T) {
+	for in, out := range cargoRequirements {
+		tmp := strings.Split(in, "|")
+
+		c, err := ParseCargoRequirement(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseCargoRequirement(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := c.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	errs := map[string]error{
+		"":            ErrEmptyConstraint,
+		"1.2,":        ErrEmptyConstraint,
+		">=*":         ErrInvalidConstraint,
+		"v1.2.3":      ErrInvalidConstraint,
+		"1.2.3+build": ErrInvalidConstraint,
+		"1.*.3":       ErrInvalidConstraint,
+		">> 1.2":      ErrInvalidConstraint,
+		"1.2 || 1.3":  ErrInvalidConstraint,
+		"1.2.3.4":     ErrInvalidSemVer,
+		"1.2.*-beta":  ErrInvalidSemVer,
+		"01.2":        ErrInvalidSemVer,
+	}
+
+	for in, out := range errs {
+		if c, err := Cargo.ParseConstraint(in); c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: Cargo.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+
+	var e *ConstraintError
+	if _, err := ParseCargoRequirement(">=1.2,  <x"); !errors.As(err, &e) || e.Offset != 8 || e.Clause != "<x" {
+		t.Errorf("FAIL: ParseCargoRequirement(>=1.2,  <x) = %v: want clause <x at offset 8", err)
+	}
+}
@@ -1,15 +1,10 @@
 package version
 
 import (
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-var regexpSigns = regexp.MustCompile(`[_\-+]`)
-var regexpDotBeforeDigit = regexp.MustCompile(`([^.\d]+)`)
-var regexpMultipleDots = regexp.MustCompile(`\.{2,}`)
-
 var specialForms = map[string]int{
 	"dev":   -6,
 	"alpha": -5,
@@ -45,6 +40,7 @@ func Compare(version1, version2, operator string) bool {
 // '4.3.2RC1' becomes '4.3.2.RC.1'.
 //
 // Then it splits the results like if you were using Split(version, '.').
+// Build metadata, anything after a + sign, is left out of the comparison.
 // Then it compares the parts starting from left to right. If a part contains
 // special version strings these are handled in the following order: any string
 // not found in this list:
@@ -60,8 +56,11 @@ func Compare(version1, version2, operator string) bool {
 //     version.CompareNormalized("1.0", "1.0b1", "ge")
 //     Returns: true
 func CompareNormalized(version1, version2, operator string) bool {
-	compare := CompareSimple(version1, version2)
+	return matchOperator(CompareSimple(version1, version2), operator)
+}
 
+// tells if the result of a comparison satisfies operator
+func matchOperator(compare int, operator string) bool {
 	switch {
 	case operator == ">" || operator == "gt":
 		return compare > 0
@@ -92,9 +91,69 @@ func CompareNormalized(version1, version2, operator string) bool {
 //     version.CompareSimple("1.0rc1", "1.0")
 //     Returns: -1
 func CompareSimple(version1, version2 string) int {
+	release1, _ := splitBuild(version1)
+	release2, _ := splitBuild(version2)
+
+	return compareParts(prepVersion(release1), prepVersion(release2))
+}
+
+// Compares two version number strings, using build metadata as a tie-breaker
+//
+// Versions are compared like CompareSimple(Normalize(version1),
+// Normalize(version2)), when equal a version without build metadata is lower
+// than one with it, and build metadata are compared identifier by identifier,
+// numerically when both are numbers.
+//
+// Usage
+//     version.CompareWithBuild("1.0.0+20130313", "1.0.0+20130314")
+//     Returns: -1
+//
+//     version.CompareWithBuild("1.0.1", "1.0.0+20130314")
+//     Returns: 1
+func CompareWithBuild(version1, version2 string) int {
+	version1, version2 = Normalize(version1), Normalize(version2)
+	if cmp := CompareSimple(version1, version2); cmp != 0 {
+		return cmp
+	}
+
+	_, build1 := splitBuild(version1)
+	_, build2 := splitBuild(version2)
+
+	return compareBuild(build1, build2)
+}
+
+func compareBuild(build1, build2 string) int {
+	if build1 == "" || build2 == "" {
+		return compareUint(uint64(len(build1)), uint64(len(build2)))
+	}
+
+	v1, v2 := strings.Split(build1, "."), strings.Split(build2, ".")
+	for i := 0; i < len(v1) && i < len(v2); i++ {
+		if cmp := compareIdentifier(trimZeros(v1[i]), trimZeros(v2[i])); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareUint(uint64(len(v1)), uint64(len(v2)))
+}
+
+// build identifiers may have leading zeros, 007 being the same number than 7
+func trimZeros(identifier string) string {
+	if !isDigits(identifier) {
+		return identifier
+	}
+
+	trimmed := strings.TrimLeft(identifier, "0")
+	if trimmed == "" {
+		return "0"
+	}
+
+	return trimmed
+}
+
+func compareParts(v1, v2 []string) int {
 	var x, r, l int = 0, 0, 0
 
-	v1, v2 := prepVersion(version1), prepVersion(version2)
 	len1, len2 := len(v1), len(v2)
 
 	if len1 > len2 {
@@ -130,16 +189,100 @@ func CompareSimple(version1, version2 string) int {
 	return 0
 }
 
+// Same than compareParts, on the parts already turned into numbers by
+// prepNumbers
+func compareNumbers(v1, v2 []int) int {
+	len1, len2 := len(v1), len(v2)
+
+	x := len1
+	if len2 > x {
+		x = len2
+	}
+
+	for i := 0; i < x; i++ {
+		r, l := 0, 0
+		if i < len1 {
+			r = v1[i]
+		}
+
+		if i < len2 {
+			l = v2[i]
+		}
+
+		if r < l {
+			return -1
+		} else if r > l {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// Splits a version with prepVersion and turns each part into a number with
+// numVersion, ready to be compared many times with compareNumbers
+func prepNumbers(version string) []int {
+	release, _ := splitBuild(version)
+	parts := prepVersion(release)
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		numbers[i] = numVersion(part)
+	}
+
+	return numbers
+}
+
+// Splits a version in its parts, the same than replacing _, - and + with a
+// dot, surrounding any run of non numbers with dots, collapsing consecutive
+// dots and splitting on them
 func prepVersion(version string) []string {
 	if len(version) == 0 {
 		return []string{""}
 	}
 
-	version = regexpSigns.ReplaceAllString(version, ".")
-	version = regexpDotBeforeDigit.ReplaceAllString(version, ".$1.")
-	version = regexpMultipleDots.ReplaceAllString(version, ".")
+	parts := make([]string, 0, 8)
+	if class := charClass(version[0]); class != classDigit {
+		parts = append(parts, "")
+	}
+
+	for i := 0; i < len(version); {
+		class := charClass(version[i])
+		if class == classSeparator {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(version) && charClass(version[i]) == class {
+			i++
+		}
+
+		parts = append(parts, version[start:i])
+	}
+
+	if class := charClass(version[len(version)-1]); class != classDigit {
+		parts = append(parts, "")
+	}
+
+	return parts
+}
+
+const (
+	classDigit = iota
+	classSeparator
+	classOther
+)
+
+func charClass(char byte) int {
+	switch {
+	case char >= '0' && char <= '9':
+		return classDigit
+	case char == '.' || char == '_' || char == '-' || char == '+':
+		return classSeparator
+	}
 
-	return strings.Split(version, ".")
+	return classOther
 }
 
 func numVersion(value string) int {
@@ -1,6 +1,8 @@
 package version
 
 import (
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -110,6 +112,32 @@ func TestCompareVersionSimple(t *testing.T) {
 	}
 }
 
+var compareWithBuildValues = map[string]int{
+	"1.0.0+20130313|1.0.0+20130313": 0,
+	"1.0.0+20130313|1.0+20130313":   0,
+	"1.0.0+20130313|1.0.0+20130314": -1,
+	"1.0.0|1.0.0+20130313":          -1,
+	"1.0.1|1.0.0+20130313":          1,
+	"1.0.0+build.9|1.0.0+build.10":  -1,
+	"1.0.0+build.007|1.0.0+build.7": 0,
+	"1.0.0+build.1|1.0.0+build":     1,
+	"1.0.0+alpha|1.0.0+1":           1,
+	"1.0-beta+2|1.0+1":              -1,
+}
+
+func TestCompareWithBuild(t *testing.T) {
+	for in, out := range compareWithBuildValues {
+		v := strings.Split(in, "|")
+		if x := CompareWithBuild(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareWithBuild(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareSimple(Normalize(v[0]), Normalize(v[1])); out == 0 && x != 0 {
+			t.Errorf("FAIL: CompareSimple(%v) = %v: want %v", in, x, 0)
+		}
+	}
+}
+
 var compareNormalizedVersionValues = map[string]bool{
 	"1.0-dev lt 1.0-dev":    false,
 	"1.0-dev < 1.0-dev":     false,
@@ -815,6 +843,10 @@ func TestCompareNormalized(t *testing.T) {
 var compareVersionValues = map[string]bool{
 	"2.3.4 < v3.1.2":           true,
 	"dev-master = 9999999-dev": true,
+	"1.0+1 = 1.0+2":            true,
+	"1.0+1 < 1.0.1+0":          true,
+	"2.0 >> 1.0":               false,
+	"1.0 << 2.0":               false,
 }
 
 func TestCompare(t *testing.T) {
@@ -825,3 +857,39 @@ func TestCompare(t *testing.T) {
 		}
 	}
 }
+
+// the regular expressions prepVersion used to be written with
+var regexpSigns = regexp.MustCompile(`[_\-+]`)
+var regexpDotBeforeDigit = regexp.MustCompile(`([^.\d]+)`)
+var regexpMultipleDots = regexp.MustCompile(`\.{2,}`)
+
+func TestPrepVersionMatchesRegexp(t *testing.T) {
+	inputs := []string{"", ".", "...", "-dev", "1.0-dev", "v1.0.0", "1.0.0RC1dev", "1.0.0.RC.15-dev", "dev-feature/foo", "2010-01-02.5", "1.0#abc", "1.0+build.5", "ü1.0ß"}
+	for in := range prepVersionValues {
+		inputs = append(inputs, in)
+	}
+	for in := range compareVersionSimpleValues {
+		inputs = append(inputs, strings.Split(in, "|")...)
+	}
+
+	for _, in := range inputs {
+		version := regexpSigns.ReplaceAllString(in, ".")
+		version = regexpDotBeforeDigit.ReplaceAllString(version, ".$1.")
+		version = regexpMultipleDots.ReplaceAllString(version, ".")
+
+		out := strings.Split(version, ".")
+		if in == "" {
+			out = []string{""}
+		}
+
+		if x := prepVersion(in); !reflect.DeepEqual(x, out) {
+			t.Errorf("FAIL: prepVersion(%q) = %q: want %q", in, x, out)
+		}
+	}
+}
+
+func BenchmarkCompareSimple(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CompareSimple("1.0.0.0-beta2", "1.0.0.0-RC1")
+	}
+}
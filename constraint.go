@@ -40,7 +40,15 @@ func (self *Constraint) GetVersion() string {
 
 // Match a given version againts the constraint
 func (self *Constraint) Match(version string) bool {
-	return Compare(version, self.version, self.operator)
+	return self.matchNormalized(Normalize(version))
+}
+
+func (self *Constraint) matchNormalized(version string) bool {
+	return CompareNormalized(version, Normalize(self.version), self.operator)
+}
+
+func (self *Constraint) matchScheme(scheme Scheme, version string) bool {
+	return matchOperator(scheme.Compare(version, self.version), self.operator)
 }
 
 // Return a string representation
@@ -0,0 +1,303 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DebianVersion is a version of a Debian package, [epoch:]upstream[-revision]
+//
+// Versions are ordered like dpkg does, see
+// https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+type DebianVersion struct {
+	original string
+	epoch    int
+	upstream string
+	revision string
+}
+
+// Debian is the scheme of Debian packages, registered as "debian"
+//
+// Constraints are comma separated relations using the operators of Debian
+// control files, <<, <=, =, >= and >>, the obsolete < and > meaning <= and >=.
+var Debian Scheme = debianScheme{}
+
+var debianOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"<<": "<",
+	"<=": "<=",
+	"<":  "<=",
+	">=": ">=",
+	">>": ">",
+	">":  ">=",
+}
+
+func init() {
+	RegisterScheme("debian", Debian)
+}
+
+// Parses a Debian package version
+//
+// The upstream version must start with a digit, invalid versions are reported
+// as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseDebian("1:2.30-1ubuntu1~20.04")
+//     v.Upstream()
+//     Returns: "2.30"
+func ParseDebian(version string) (DebianVersion, error) {
+	v := DebianVersion{original: version}
+
+	rest := strings.TrimSpace(version)
+	if rest == "" {
+		return DebianVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if strings.ContainsAny(rest, " \t\n\r") {
+		return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "embedded spaces"}
+	}
+
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := strconv.Atoi(rest[:i])
+		if err != nil || !isDigits(rest[:i]) {
+			return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "invalid epoch " + strconv.Quote(rest[:i])}
+		}
+		v.epoch = epoch
+		rest = rest[i+1:]
+	}
+
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.revision = rest[i+1:]
+		if v.revision == "" {
+			return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "empty revision"}
+		}
+		rest = rest[:i]
+	}
+
+	v.upstream = rest
+	if v.upstream == "" || v.upstream[0] < '0' || v.upstream[0] > '9' {
+		return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "upstream version does not start with a digit"}
+	}
+
+	if i := strings.IndexFunc(v.upstream, isNotDebianChar(".+~-:")); i >= 0 {
+		return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "invalid character in upstream version"}
+	}
+
+	if i := strings.IndexFunc(v.revision, isNotDebianChar(".+~")); i >= 0 {
+		return DebianVersion{}, &ParseError{version, ErrInvalidVersion, "invalid character in revision"}
+	}
+
+	return v, nil
+}
+
+func isNotDebianChar(symbols string) func(rune) bool {
+	return func(char rune) bool {
+		return !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+			strings.ContainsRune(symbols, char))
+	}
+}
+
+// Compares two Debian package versions, like dpkg --compare-versions
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareDebian("1.0~rc1-1", "1.0-1")
+//     Returns: -1
+//
+//     version.CompareDebian("1:0.9", "2.0")
+//     Returns: 1
+func CompareDebian(version1, version2 string) int {
+	v1, err1 := ParseDebian(version1)
+	v2, err2 := ParseDebian(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Parses comma separated Debian relations, like ">= 1.0, << 2.0"
+//
+// Usage
+//     c, _ := version.ParseDebianConstraint(">= 2.30-1, << 3")
+//     c.Match("2.30-1ubuntu1~20.04")
+//     Returns: true
+func ParseDebianConstraint(constraint string) (*ConstraintGroup, error) {
+	return parseRelations(Debian, constraint, debianOperators)
+}
+
+// Return the epoch, 0 when not given
+func (self DebianVersion) Epoch() int {
+	return self.epoch
+}
+
+// Return the upstream version
+func (self DebianVersion) Upstream() string {
+	return self.upstream
+}
+
+// Return the Debian revision, empty for native packages
+func (self DebianVersion) Revision() string {
+	return self.revision
+}
+
+// Return the string given to ParseDebian
+func (self DebianVersion) Original() string {
+	return self.original
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self DebianVersion) Compare(other DebianVersion) int {
+	if cmp := compareUint(uint64(self.epoch), uint64(other.epoch)); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := verrevcmp(self.upstream, other.upstream); cmp != 0 {
+		return cmp
+	}
+
+	return verrevcmp(self.revision, other.revision)
+}
+
+// Return true if both versions are equal
+func (self DebianVersion) Equal(other DebianVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self DebianVersion) Less(other DebianVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version, the epoch being left out when 0
+func (self DebianVersion) String() string {
+	version := self.upstream
+	if self.epoch != 0 {
+		version = strconv.Itoa(self.epoch) + ":" + version
+	}
+
+	if self.revision != "" {
+		version = version + "-" + self.revision
+	}
+
+	return version
+}
+
+// Compares upstream versions or revisions like dpkg
+//
+// Strings are compared alternating non digit parts, where letters sort before
+// other characters and ~ before anything, even the end of the string, and
+// digit parts compared numerically.
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac, bc := debianOrder(a, i), debianOrder(b, j)
+			if ac != bc {
+				return compareInt(ac, bc)
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = compareInt(int(a[i]), int(b[j]))
+			}
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+
+	return 0
+}
+
+// weight of the character at i, the end of the string and digits weighting 0
+func debianOrder(s string, i int) int {
+	if i >= len(s) || isDigit(s[i]) {
+		return 0
+	}
+
+	char := s[i]
+	switch {
+	case char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z':
+		return int(char)
+	case char == '~':
+		return -1
+	}
+
+	return int(char) + 256
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+
+	return 0
+}
+
+type debianScheme struct{}
+
+func (debianScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseDebian(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (debianScheme) Compare(version1, version2 string) int {
+	return CompareDebian(version1, version2)
+}
+
+func (debianScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(DebianVersion).Compare(v2.(DebianVersion))
+}
+
+func (debianScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseDebianConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (debianScheme) Format(version string) (string, error) {
+	v, err := ParseDebian(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
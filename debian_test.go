@@ -0,0 +1,150 @@
+package version
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var debianValues = map[string][3]string{
+	"1.0":                   {"0", "1.0", ""},
+	"1.0-1":                 {"0", "1.0", "1"},
+	"0:1.0-1":               {"0", "1.0", "1"},
+	"1:2.30-1ubuntu1~20.04": {"1", "2.30", "1ubuntu1~20.04"},
+	"2.30-1-2":              {"0", "2.30-1", "2"},
+	"1:2.0:3-1":             {"1", "2.0:3", "1"},
+	"1.0~rc1+dfsg-0.1":      {"0", "1.0~rc1+dfsg", "0.1"},
+}
+
+func TestParseDebian(t *testing.T) {
+	for in, out := range debianValues {
+		v, err := ParseDebian(in)
+		x := [3]string{strconv.Itoa(v.Epoch()), v.Upstream(), v.Revision()}
+		if err != nil || x != out || v.Original() != in {
+			t.Errorf("FAIL: ParseDebian(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+}
+
+var invalidDebianValues = []string{
+	"a1.0", "1.0-", ":1.0", "a:1.0", "1.0 -1", "1.0_1", "1.0-1:2", "-1", "1:",
+}
+
+func TestParseDebianInvalid(t *testing.T) {
+	for _, in := range invalidDebianValues {
+		if v, err := ParseDebian(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseDebian(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+
+	if _, err := ParseDebian(" "); !errors.Is(err, ErrEmptyVersion) {
+		t.Errorf("FAIL: ParseDebian() error = %v: want %v", err, ErrEmptyVersion)
+	}
+}
+
+// as given by dpkg --compare-versions
+var compareDebianValues = map[string]int{
+	"1.0|1.0":                           0,
+	"1.0|1.0-0":                         0,
+	"1.0-1|1.0-01":                      0,
+	"0:1.0|1.0":                         0,
+	"1.0|1.1":                           -1,
+	"1.2|1.10":                          -1,
+	"1.0~rc1|1.0":                       -1,
+	"1.0~~|1.0~":                        -1,
+	"1.0~|1.0":                          -1,
+	"1.0|1.0a":                          -1,
+	"1.0a|1.0+":                         -1,
+	"1.0+|1.0.1":                        -1,
+	"1.0-1|1.0-1ubuntu1":                -1,
+	"1:2.30-1ubuntu1~20.04|2.31":        1,
+	"2.30-1ubuntu1~20.04|2.30-1ubuntu1": -1,
+	"1:0.9|2.0":                         1,
+	"1.0|a1.0":                          1,
+	"1.0.0|1.0":                         1,
+	"7.6p2-4|7.6-0":                     1,
+	"1.0.3-3|1.0-1":                     1,
+	"1.3-1|1.2.2-2":                     1,
+	"1.2.2-2|1.2.2-1":                   1,
+	"1.0-1|1.0.0-1":                     -1,
+}
+
+func TestCompareDebian(t *testing.T) {
+	for in, out := range compareDebianValues {
+		v := strings.Split(in, "|")
+		if x := CompareDebian(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareDebian(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareDebian(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareDebian(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := Debian.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: Debian.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var debianConstraints = map[string]bool{
+	">= 2.30-1, << 3|2.30-1ubuntu1~20.04": true,
+	">= 2.30-1, << 3|3~beta1":             true,
+	">= 2.30-1, << 3|3":                   false,
+	"(>> 1.0)|1.0":                        false,
+	"(>> 1.0)|1.0+b1":                     true,
+	"= 1:1.0|1.0":                         false,
+	"= 1.0|1.0-0":                         true,
+	"1.0|1.0":                             true,
+	"<= 1.0~rc1|1.0~beta1":                true,
+	"< 1.0|1.0":                           true,
+	"> 1.0|1.0":                           true,
+	">>1.0,<<2.0|1.5":                     true,
+	"<< 3|garbage":                        false,
+	"<< 3|":                               false,
+}
+
+func TestParseDebianConstraint(t *testing.T) {
+	for in, out := range debianConstraints {
+		tmp := strings.Split(in, "|")
+
+		c, err := ParseDebianConstraint(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseDebianConstraint(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := c.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var debianConstraintErrors = map[string]error{
+	"":              ErrEmptyConstraint,
+	">= 1.0,":       ErrEmptyConstraint,
+	"!= 1.0":        ErrInvalidConstraint,
+	">= 1.0, << a1": ErrInvalidVersion,
+}
+
+func TestParseDebianConstraintErrors(t *testing.T) {
+	for in, out := range debianConstraintErrors {
+		c, err := Debian.ParseConstraint(in)
+		if c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: Debian.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+
+	_, err := ParseDebianConstraint(">= 1.0, << a1")
+	if e, ok := err.(*ConstraintError); !ok || e.Clause != "<< a1" || e.Offset != 8 {
+		t.Errorf("FAIL: ParseDebianConstraint(>= 1.0, << a1) error = %#v: want clause << a1 at offset 8", err)
+	}
+}
+
+func TestDebianFormat(t *testing.T) {
+	for in, out := range map[string]string{"0:1.0-1": "1.0-1", "1:1.0": "1:1.0", " 1.0 ": "1.0"} {
+		if x, err := Debian.Format(in); x != out || err != nil {
+			t.Errorf("FAIL: Debian.Format(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+}
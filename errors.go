@@ -0,0 +1,59 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyVersion    = errors.New("version: empty version string")
+	ErrInvalidVersion  = errors.New("version: invalid version string")
+	ErrUnknownModifier = errors.New("version: unknown version modifier")
+	ErrTooManySegments = errors.New("version: too many version segments")
+	ErrBadDate         = errors.New("version: invalid date-based version")
+	ErrInvalidSemVer   = errors.New("version: invalid semantic version")
+
+	ErrEmptyConstraint   = errors.New("version: empty constraint")
+	ErrInvalidConstraint = errors.New("version: invalid constraint")
+)
+
+// ParseError describes why a version string was rejected
+//
+// Err is one of the Err* values of this package, so callers can test the
+// reason with errors.Is.
+type ParseError struct {
+	Version string
+	Err     error
+	Detail  string
+}
+
+func (self *ParseError) Error() string {
+	if self.Detail == "" {
+		return fmt.Sprintf("%v: %q", self.Err, self.Version)
+	}
+
+	return fmt.Sprintf("%v: %q (%s)", self.Err, self.Version, self.Detail)
+}
+
+func (self *ParseError) Unwrap() error {
+	return self.Err
+}
+
+// ConstraintError describes why a constraint string was rejected
+//
+// Clause is the part of Constraint that could not be parsed, starting at byte
+// Offset, and Err the reason, usually a *ParseError for its version.
+type ConstraintError struct {
+	Constraint string
+	Clause     string
+	Offset     int
+	Err        error
+}
+
+func (self *ConstraintError) Error() string {
+	return fmt.Sprintf("version: invalid constraint %q: clause %q at offset %d: %v", self.Constraint, self.Clause, self.Offset, self.Err)
+}
+
+func (self *ConstraintError) Unwrap() error {
+	return self.Err
+}
@@ -0,0 +1,36 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+var parseErrorValues = map[string]*ParseError{
+	`version: unknown version modifier: "1.0-foo" (foo)`: {"1.0-foo", ErrUnknownModifier, "foo"},
+	`version: empty version string: ""`:                  {"", ErrEmptyVersion, ""},
+}
+
+func TestParseError(t *testing.T) {
+	for out, in := range parseErrorValues {
+		if x := in.Error(); x != out {
+			t.Errorf("FAIL: Error() = %v: want %v", x, out)
+		}
+
+		if !errors.Is(in, in.Err) {
+			t.Errorf("FAIL: errors.Is(%v, %v) = false: want true", in, in.Err)
+		}
+	}
+}
+
+func TestConstraintError(t *testing.T) {
+	err := &ConstraintError{">=1.0, <2.0-foo", "<2.0-foo", 7, &ParseError{"2.0-foo", ErrUnknownModifier, "foo"}}
+	out := `version: invalid constraint ">=1.0, <2.0-foo": clause "<2.0-foo" at offset 7: version: unknown version modifier: "2.0-foo" (foo)`
+
+	if x := err.Error(); x != out {
+		t.Errorf("FAIL: Error() = %v: want %v", x, out)
+	}
+
+	if !errors.Is(err, ErrUnknownModifier) {
+		t.Errorf("FAIL: errors.Is(%v, %v) = false: want true", err, ErrUnknownModifier)
+	}
+}
@@ -0,0 +1,291 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var regexpPseudoVersion = regexp.MustCompile(`^v\d+\.(?:0\.0-|\d+\.\d+-(?:[^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+const pseudoVersionTime = "20060102150405"
+
+// ModuleVersion is a version of a Go module, like v1.2.3, v2.3.4+incompatible
+// or the pseudo-version v0.0.0-20191109021931-daa7c04131f5
+//
+// Versions are ordered like the go command does, see
+// https://go.dev/ref/mod#versions, pseudo-versions being pre-releases of the
+// version following their base.
+type ModuleVersion struct {
+	original string
+	semver   SemVer
+}
+
+// GoModules is the scheme of Go modules, registered as "go"
+//
+// Go modules have no constraint syntax, constraints are comma separated
+// comparisons, like >= v1.2.0, < v2.0.0.
+var GoModules Scheme = goModulesScheme{}
+
+var goModulesOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"==": "=",
+	"!=": "!=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+}
+
+func init() {
+	RegisterScheme("go", GoModules)
+}
+
+// Parses a Go module version
+//
+// Versions are Semantic Versioning 2.0.0 versions with a v prefix, where
+// v1 and v1.2 are accepted as shorthands of v1.0.0 and v1.2.0. Invalid
+// versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseModuleVersion("v1.2.4-0.20191109021931-daa7c04131f5")
+//     v.PseudoBase()
+//     Returns: "v1.2.3"
+func ParseModuleVersion(version string) (ModuleVersion, error) {
+	if version == "" {
+		return ModuleVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if version[0] != 'v' {
+		return ModuleVersion{}, &ParseError{version, ErrInvalidVersion, "missing v prefix"}
+	}
+
+	rest := version[1:]
+
+	// shorthands can not have a pre-release nor build metadata
+	core := rest
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+
+	switch strings.Count(core, ".") {
+	case 0:
+		if core != rest {
+			return ModuleVersion{}, &ParseError{version, ErrInvalidVersion, "pre-release or build of a shorthand version"}
+		}
+		rest = rest + ".0.0"
+	case 1:
+		if core != rest {
+			return ModuleVersion{}, &ParseError{version, ErrInvalidVersion, "pre-release or build of a shorthand version"}
+		}
+		rest = rest + ".0"
+	}
+
+	v, err := ParseSemVer(rest)
+	if err != nil {
+		detail := ""
+		if e, ok := err.(*ParseError); ok {
+			detail = e.Detail
+		}
+
+		return ModuleVersion{}, &ParseError{version, ErrInvalidVersion, detail}
+	}
+	v.original = version
+
+	mv := ModuleVersion{version, v}
+	if mv.IsIncompatible() && v.major < 2 {
+		return ModuleVersion{}, &ParseError{version, ErrInvalidVersion, "+incompatible with major version v" + strconv.FormatUint(v.major, 10)}
+	}
+
+	return mv, nil
+}
+
+// Compares two Go module versions, like golang.org/x/mod/semver.Compare
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareModuleVersion("v1.2.4-0.20191109021931-daa7c04131f5", "v1.2.3")
+//     Returns: 1
+//
+//     version.CompareModuleVersion("v2.3.4+incompatible", "v2.3.4")
+//     Returns: 0
+func CompareModuleVersion(version1, version2 string) int {
+	v1, err1 := ParseModuleVersion(version1)
+	v2, err2 := ParseModuleVersion(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the string given to ParseModuleVersion
+func (self ModuleVersion) Original() string {
+	return self.original
+}
+
+// Return the major version prefix, v2 for v2.3.4
+func (self ModuleVersion) Major() string {
+	return "v" + strconv.FormatUint(self.semver.major, 10)
+}
+
+// Return the pre-release, 0.20191109021931-daa7c04131f5 for
+// v1.2.4-0.20191109021931-daa7c04131f5, empty for releases
+func (self ModuleVersion) Prerelease() string {
+	return strings.Join(self.semver.prerelease, ".")
+}
+
+// Return true for versions of modules without go.mod past v1, like
+// v2.3.4+incompatible
+func (self ModuleVersion) IsIncompatible() bool {
+	return len(self.semver.build) == 1 && self.semver.build[0] == "incompatible"
+}
+
+// Return the canonical form of the version, as written in go.mod files:
+// shorthands completed and build metadata other than +incompatible dropped
+func (self ModuleVersion) Canonical() string {
+	canonical := self.semver
+	canonical.build = nil
+	if self.IsIncompatible() {
+		canonical.build = self.semver.build
+	}
+
+	return "v" + canonical.String()
+}
+
+// Return true if the version is written in its canonical form
+func (self ModuleVersion) IsCanonical() bool {
+	return self.original == self.Canonical()
+}
+
+// Return true for pseudo-versions, versions generated by the go command for
+// commits without tag
+func (self ModuleVersion) IsPseudo() bool {
+	return strings.Count(self.original, "-") >= 2 && regexpPseudoVersion.MatchString(self.original)
+}
+
+// Return the version a pseudo-version is based on, +incompatible being kept
+//
+// Empty for pseudo-versions without base, like
+// v0.0.0-20191109021931-daa7c04131f5, and for versions that are not
+// pseudo-versions.
+func (self ModuleVersion) PseudoBase() string {
+	if !self.IsPseudo() {
+		return ""
+	}
+
+	prerelease := self.semver.prerelease
+	base := self.semver
+	if !self.IsIncompatible() {
+		base.build = nil
+	}
+
+	switch {
+	case len(prerelease) == 1:
+		// vX.0.0-yyyymmddhhmmss-abcdefabcdef
+		return ""
+	case len(prerelease) == 2 && prerelease[0] == "0":
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef
+		if base.patch == 0 {
+			return ""
+		}
+		base.prerelease = nil
+		base.patch--
+	default:
+		// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef
+		base.prerelease = prerelease[:len(prerelease)-2]
+	}
+
+	return "v" + base.String()
+}
+
+// Return the commit time of a pseudo-version
+func (self ModuleVersion) PseudoTime() (time.Time, error) {
+	if !self.IsPseudo() {
+		return time.Time{}, &ParseError{self.original, ErrInvalidVersion, "not a pseudo-version"}
+	}
+
+	timestamp, _, _ := strings.Cut(self.pseudoSuffix(), "-")
+
+	return time.Parse(pseudoVersionTime, timestamp)
+}
+
+// Return the commit hash prefix of a pseudo-version, empty for other versions
+func (self ModuleVersion) PseudoRevision() string {
+	if !self.IsPseudo() {
+		return ""
+	}
+
+	_, revision, _ := strings.Cut(self.pseudoSuffix(), "-")
+
+	return revision
+}
+
+// the yyyymmddhhmmss-abcdefabcdef ending of a pseudo-version
+func (self ModuleVersion) pseudoSuffix() string {
+	prerelease := self.semver.prerelease
+
+	return prerelease[len(prerelease)-1]
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self ModuleVersion) Compare(other ModuleVersion) int {
+	return self.semver.Compare(other.semver)
+}
+
+// Return true if both versions are equal
+func (self ModuleVersion) Equal(other ModuleVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self ModuleVersion) Less(other ModuleVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the canonical form of the version
+func (self ModuleVersion) String() string {
+	return self.Canonical()
+}
+
+type goModulesScheme struct{}
+
+func (goModulesScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseModuleVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (goModulesScheme) Compare(version1, version2 string) int {
+	return CompareModuleVersion(version1, version2)
+}
+
+func (goModulesScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(ModuleVersion).Compare(v2.(ModuleVersion))
+}
+
+func (goModulesScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := parseRelations(GoModules, constraint, goModulesOperators)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (goModulesScheme) Format(version string) (string, error) {
+	v, err := ParseModuleVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.Canonical(), nil
+}
@@ -0,0 +1,122 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var moduleVersionValues = map[string]string{
+	"v1.2.3":                               "v1.2.3",
+	"v1":                                   "v1.0.0",
+	"v1.2":                                 "v1.2.0",
+	"v1.2.3-beta.1":                        "v1.2.3-beta.1",
+	"v1.2.3+meta":                          "v1.2.3",
+	"v2.3.4+incompatible":                  "v2.3.4+incompatible",
+	"v0.0.0-20191109021931-daa7c04131f5":   "v0.0.0-20191109021931-daa7c04131f5",
+	"v1.2.4-0.20191109021931-daa7c04131f5": "v1.2.4-0.20191109021931-daa7c04131f5",
+}
+
+func TestParseModuleVersion(t *testing.T) {
+	for in, out := range moduleVersionValues {
+		v, err := ParseModuleVersion(in)
+		if err != nil || v.Canonical() != out || v.Original() != in {
+			t.Errorf("FAIL: ParseModuleVersion(%v) = %v, %v: want %v", in, v, err, out)
+		}
+
+		if x := v.IsCanonical(); x != (in == out) {
+			t.Errorf("FAIL: ParseModuleVersion(%v).IsCanonical() = %v: want %v", in, x, in == out)
+		}
+
+		if x, err := GoModules.Format(in); x != out || err != nil {
+			t.Errorf("FAIL: GoModules.Format(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"1.2.3", "v1.2-pre", "v1+meta", "v01.2.3", "v1.2.3.4", "v1.0.0+incompatible", "v0.1.0+incompatible", "vx"} {
+		if v, err := ParseModuleVersion(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseModuleVersion(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+type pseudoVersion struct {
+	base     string
+	time     string
+	revision string
+}
+
+var pseudoVersionValues = map[string]pseudoVersion{
+	"v0.0.0-20191109021931-daa7c04131f5":                {"", "2019-11-09T02:19:31Z", "daa7c04131f5"},
+	"v1.2.4-0.20191109021931-daa7c04131f5":              {"v1.2.3", "2019-11-09T02:19:31Z", "daa7c04131f5"},
+	"v1.2.3-pre.0.20191109021931-daa7c04131f5":          {"v1.2.3-pre", "2019-11-09T02:19:31Z", "daa7c04131f5"},
+	"v2.0.1-0.20191109021931-daa7c04131f5+incompatible": {"v2.0.0+incompatible", "2019-11-09T02:19:31Z", "daa7c04131f5"},
+}
+
+func TestModuleVersionPseudo(t *testing.T) {
+	for in, out := range pseudoVersionValues {
+		v, _ := ParseModuleVersion(in)
+		if !v.IsPseudo() {
+			t.Errorf("FAIL: ParseModuleVersion(%v).IsPseudo() = false: want true", in)
+		}
+
+		date, err := v.PseudoTime()
+		x := pseudoVersion{v.PseudoBase(), date.Format(time.RFC3339), v.PseudoRevision()}
+		if x != out || err != nil {
+			t.Errorf("FAIL: ParseModuleVersion(%v) pseudo = %+v, %v: want %+v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"v1.2.3", "v1.2.3-beta.1", "v1.2.3-20191109021931-daa7c04131f5"} {
+		v, _ := ParseModuleVersion(in)
+		if _, err := v.PseudoTime(); v.IsPseudo() || v.PseudoBase() != "" || v.PseudoRevision() != "" || err == nil {
+			t.Errorf("FAIL: ParseModuleVersion(%v).IsPseudo() = true: want false", in)
+		}
+	}
+}
+
+// ordered like the go command does
+var moduleVersionOrdering = []string{
+	"v0.0.0-20191109021931-daa7c04131f5",
+	"v0.0.0-20200101000000-aaaaaaaaaaaa",
+	"v0.1.0",
+	"v1.2.3-pre",
+	"v1.2.3-pre.0.20191109021931-daa7c04131f5",
+	"v1.2.3",
+	"v1.2.4-0.20191109021931-daa7c04131f5",
+	"v1.2.4",
+	"v2.0.0+incompatible",
+	"v2.3.4",
+}
+
+func TestCompareModuleVersion(t *testing.T) {
+	for i := range moduleVersionOrdering {
+		for j := range moduleVersionOrdering {
+			out := compareInt(i, j)
+			if x := CompareModuleVersion(moduleVersionOrdering[i], moduleVersionOrdering[j]); x != out {
+				t.Errorf("FAIL: CompareModuleVersion(%v, %v) = %v: want %v", moduleVersionOrdering[i], moduleVersionOrdering[j], x, out)
+			}
+		}
+	}
+
+	for in, out := range map[string]int{"v1|v1.0.0": 0, "v2.3.4+incompatible|v2.3.4": 0, "1.0.0|v0.0.1": -1, "v1|v1.2.3-x": -1} {
+		v := strings.Split(in, "|")
+		if x := GoModules.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: GoModules.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestGoModulesConstraint(t *testing.T) {
+	c, err := GoModules.ParseConstraint(">= v1.2.0, < v2")
+	if err != nil {
+		t.Fatalf("FAIL: GoModules.ParseConstraint(>= v1.2.0, < v2) error = %v", err)
+	}
+
+	for in, out := range map[string]bool{"v1.2.0": true, "v1.9.9-0.20191109021931-daa7c04131f5": true, "v2.0.0+incompatible": false, "v1.1": false, "garbage": false, "": false} {
+		if x := c.Match(in); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
@@ -6,8 +6,21 @@ import (
 	"strings"
 )
 
+var versionRegex = `v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + modifierRegex
+
 type ConstraintGroup struct {
-	constraints []*Constraint
+	constraints  []*Constraint
+	alternatives []*ConstraintGroup
+	scheme       Scheme
+
+	// constraint versions normalized once when added, for matchNormalized
+	normalized []normalizedVersion
+
+	// rejects versions before the constraints are checked, for the rules of
+	// some dialects on pre-releases
+	admit func(version string, constraints []*Constraint) bool
+	// the versions admit lets through, as a split range for Range
+	admitted func(constraints []*Constraint) *Range
 }
 
 // Return a new NewConstrainGroup
@@ -26,7 +39,8 @@ func NewConstrainGroup() *ConstraintGroup {
 //
 // Range: By using comparison operators you can specify ranges of valid versions. 
 // Valid operators are >, >=, <, <=, !=. An example range would be >=1.0. You can 
-// define multiple ranges, separated by a comma: >=1.0,<2.0.
+// define multiple ranges, separated by a comma or a space: >=1.0,<2.0 or
+// >=1.0 <2.0.
 // 
 // Wildcard: You can specify a pattern with a * wildcard. 1.0.* is the equivalent 
 // of >=1.0,<1.1.
@@ -41,19 +55,57 @@ func NewConstrainGroup() *ConstraintGroup {
 // using ~ specifies a minimum version, but allows the last digit specified 
 // to go up.
 //
+// Next Significant Release (Caret Operator): The ^ operator allows every
+// update that does not modify the left-most non-zero digit: ^1.2.3 is
+// equivalent to >=1.2.3,<2.0.0 while ^0.3 is equivalent to >=0.3,<0.4 and
+// ^0.0.3 to >=0.0.3,<0.0.4.
+//
+// Hyphenated Range: An inclusive set of versions, 1.0 - 2.0 is equivalent to
+// >=1.0,<2.1 as a partial upper bound allows every release it prefixes, while
+// 1.0.0 - 2.1.0 is equivalent to >=1.0.0,<=2.1.0.
+//
+// Logical OR: Constraints separated by || (or a single |) are alternatives,
+// a version matching any of them matches the group: ~1.2 || ^2.0. Commas bind
+// tighter than ||, so >=1.0,<1.1 || >=1.2 reads as (>=1.0,<1.1) || >=1.2.
+//
 // By default only stable releases are taken into consideration. If you would like 
 // to also get RC, beta, alpha or dev versions of your dependencies you can do so 
 // using stability flags. To change that for all packages instead of doing per 
 // dependency you can also use the minimum-stability setting.
 // 
 // From: http://getcomposer.org/doc/01-basic-usage.md#package-versions
+//
+// Constraints that can not be understood are kept as exact matches on the
+// given string, use ParseConstraintGroup to detect them.
 func NewConstrainGroupFromString(name string) *ConstraintGroup {
 	group := new(ConstraintGroup)
-	group.fromString(name)
+	group.fromString(name, 0)
 
 	return group
 }
 
+// Return a new ConstraintGroup parsed from a string, failing on malformed
+// constraints
+//
+// Same syntax than NewConstrainGroupFromString, but empty constraints and
+// invalid versions are reported as a *ConstraintError holding the offending
+// clause and its byte offset.
+//
+// Usage
+//     _, err := version.ParseConstraintGroup(">=1.0, <2.0-foo")
+//     err.Error()
+//     Returns: version: invalid constraint ">=1.0, <2.0-foo": clause "<2.0-foo" at offset 7: version: unknown version modifier: "2.0-foo" (foo)
+func ParseConstraintGroup(constraint string) (*ConstraintGroup, error) {
+	group := new(ConstraintGroup)
+	if err := group.fromString(constraint, 0); err != nil {
+		err.Constraint = constraint
+
+		return nil, err
+	}
+
+	return group, nil
+}
+
 // Adds a Contraint to the group
 func (self *ConstraintGroup) AddConstraint(constraint ...*Constraint) {
 	if self.constraints == nil {
@@ -61,6 +113,18 @@ func (self *ConstraintGroup) AddConstraint(constraint ...*Constraint) {
 	}
 
 	self.constraints = append(self.constraints, constraint...)
+
+	if self.scheme == nil {
+		for _, added := range constraint {
+			self.normalized = append(self.normalized, normalizedVersion{added.version, Normalize(added.version)})
+		}
+	}
+}
+
+// a constraint version and its normalized form
+type normalizedVersion struct {
+	version    string
+	normalized string
 }
 
 // Return all the constraints
@@ -68,6 +132,21 @@ func (self *ConstraintGroup) GetConstraints() []*Constraint {
 	return self.constraints
 }
 
+// Adds alternative groups, a version must match at least one of them besides
+// the constraints of the group itself
+func (self *ConstraintGroup) AddAlternative(group ...*ConstraintGroup) {
+	if self.alternatives == nil {
+		self.alternatives = make([]*ConstraintGroup, 0)
+	}
+
+	self.alternatives = append(self.alternatives, group...)
+}
+
+// Return all the alternative groups
+func (self *ConstraintGroup) GetAlternatives() []*ConstraintGroup {
+	return self.alternatives
+}
+
 // Match a given version againts the group
 //
 // Usage
@@ -78,52 +157,222 @@ func (self *ConstraintGroup) GetConstraints() []*Constraint {
 //     c := version.NewConstrainGroupFromString("~1.2.3")
 //     c.Match("1.2.3.5")
 //     Returns: true
+//
+//     c := version.NewConstrainGroupFromString("~1.2 || ^2.0")
+//     c.Match("2.5.0")
+//     Returns: true
 func (self *ConstraintGroup) Match(version string) bool {
+	if self.scheme != nil {
+		return self.matchScheme(version)
+	}
+
+	return self.matchNormalized(Normalize(version))
+}
+
+func (self *ConstraintGroup) matchNormalized(version string) bool {
+	for i, constraint := range self.constraints {
+		// the cached form is only used while the constraint version is the
+		// one it was computed from
+		if i < len(self.normalized) && self.normalized[i].version == constraint.version {
+			if CompareNormalized(version, self.normalized[i].normalized, constraint.operator) == false {
+				return false
+			}
+		} else if constraint.matchNormalized(version) == false {
+			return false
+		}
+	}
+
+	if len(self.alternatives) == 0 {
+		return true
+	}
+
+	for _, alternative := range self.alternatives {
+		if alternative.matchNormalized(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches groups built by the parsers of other schemes, versions being
+// compared with the scheme rather than normalized
+func (self *ConstraintGroup) matchScheme(version string) bool {
+	// strings the scheme does not take as versions match nothing
+	if _, err := self.scheme.Parse(version); err != nil {
+		return false
+	}
+
+	if self.admit != nil && !self.admit(version, self.constraints) {
+		return false
+	}
+
 	for _, constraint := range self.constraints {
-		if constraint.Match(version) == false {
+		if constraint.matchScheme(self.scheme, version) == false {
 			return false
 		}
 	}
 
-	return true
+	if len(self.alternatives) == 0 {
+		return true
+	}
+
+	for _, alternative := range self.alternatives {
+		if alternative.Match(version) {
+			return true
+		}
+	}
+
+	return false
 }
 
-func (self *ConstraintGroup) fromString(constraint string) bool {
-	result := RegFind(`(?i)^([^,\s]*?)@(stable|RC|beta|alpha|dev)$`, constraint)
-	if result != nil {
+var regexpOr = regexp.MustCompile(`\s*\|\|?\s*`)
+var regexpStabilityFlag = regexp.MustCompile(`(?i)^([^,\s]*?)@(stable|RC|beta|alpha|dev)$`)
+var regexpDevReference = regexp.MustCompile(`(?i)^(dev-[^,\s@]+?|[^,\s@]+?\.x-dev)#.+$`)
+
+// parses constraint into the group, offset being the position of constraint
+// within the whole string given by the user
+//
+// The group is always filled, falling back to exact matches on whatever can
+// not be understood, the first problem found is reported besides.
+func (self *ConstraintGroup) fromString(constraint string, offset int) *ConstraintError {
+	var failure *ConstraintError
+
+	bounds := regexpOr.FindAllStringIndex(constraint, -1)
+	if len(bounds) > 0 {
+		start := 0
+		for _, bound := range append(bounds, []int{len(constraint), len(constraint)}) {
+			group := new(ConstraintGroup)
+			if err := group.fromString(constraint[start:bound[0]], offset+start); err != nil && failure == nil {
+				failure = err
+			}
+
+			self.AddAlternative(group)
+			start = bound[1]
+		}
+
+		return failure
+	}
+
+	// a stability flag on a caret constraint lowers its bound, so it is kept
+	// for parseConstraint
+	result := regexpStabilityFlag.FindStringSubmatch(constraint)
+	if result != nil && !strings.HasPrefix(constraint, "^") {
 		constraint = result[1]
 		if constraint == "" {
 			constraint = "*"
 		}
 	}
 
-	result = RegFind(`(?i)^(dev-[^,\s@]+?|[^,\s@]+?\.x-dev)#.+$`, constraint)
+	result = regexpDevReference.FindStringSubmatch(constraint)
 	if result != nil {
 		if result[1] != "" {
 			constraint = result[1]
 		}
 	}
 
-	constraints := RegSplit(`\s*,\s*`, strings.Trim(constraint, " "))
+	clauses := splitClauses(constraint)
+	if len(clauses) == 0 {
+		constraints, _ := self.parseConstraint("")
+		self.AddConstraint(constraints...)
+
+		return &ConstraintError{Clause: constraint, Offset: offset, Err: ErrEmptyConstraint}
+	}
+
+	for _, clause := range clauses {
+		constraints, err := self.parseConstraint(clause.text)
+		self.AddConstraint(constraints...)
+
+		if err != nil && failure == nil {
+			failure = &ConstraintError{Clause: clause.text, Offset: offset + clause.offset, Err: err}
+		}
+	}
+
+	return failure
+}
+
+var regexpLoneOperator = regexp.MustCompile(`^(<>|!=|>=?|<=?|==?)$`)
+
+type clause struct {
+	text   string
+	offset int
+}
+
+// Splits an AND constraint on commas and whitespace
+//
+// Operators followed by a space, hyphenated ranges and aliases span several
+// words separated by spaces, those are kept together as a single clause.
+func splitClauses(constraint string) []clause {
+	words := make([]clause, 0)
+	joined := make([]bool, 0)
+
+	comma := false
+	for i := 0; i < len(constraint); {
+		if constraint[i] == ',' || isSpace(constraint[i]) {
+			comma = comma || constraint[i] == ','
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(constraint) && constraint[i] != ',' && !isSpace(constraint[i]) {
+			i++
+		}
 
-	if len(constraints) > 1 {
-		for _, part := range constraints {
-			self.AddConstraint(self.parseConstraint(part)...)
+		words = append(words, clause{constraint[start:i], start})
+		joined = append(joined, !comma)
+		comma = false
+	}
+
+	// true if words from..to are only separated by spaces
+	spaced := func(from, to int) bool {
+		for i := from + 1; i <= to; i++ {
+			if !joined[i] {
+				return false
+			}
 		}
 
 		return true
 	}
 
-	self.AddConstraint(self.parseConstraint(constraints[0])...)
+	clauses := make([]clause, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		last := i
+		if i+1 < len(words) && regexpLoneOperator.MatchString(words[i].text) && spaced(i, i+1) {
+			last = i + 1
+		}
 
-	return true
+		if last+2 < len(words) && spaced(last, last+2) &&
+			(words[last+1].text == "-" || words[last+1].text == "as") {
+			last = last + 2
+		}
+
+		end := words[last].offset + len(words[last].text)
+		clauses = append(clauses, clause{constraint[words[i].offset:end], words[i].offset})
+		i = last
+	}
+
+	return clauses
 }
 
-func (self *ConstraintGroup) parseConstraint(constraint string) []*Constraint {
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
+
+var regexpClauseStability = regexp.MustCompile(`(?i)^([^,\s]+?)@(stable|RC|beta|alpha|dev)$`)
+var regexpAnyVersion = regexp.MustCompile(`^[x*](\.[x*])*$`)
+var regexpHyphenRange = regexp.MustCompile(`(?i)^(` + versionRegex + `) +- +(` + versionRegex + `)$`)
+var regexpTilde = regexp.MustCompile(`(?i)^~(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + modifierRegex + `?$`)
+var regexpCaret = regexp.MustCompile(`(?i)^\^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + modifierRegex + `?$`)
+var regexpWildcard = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?\.[x*]$`)
+var regexpOperator = regexp.MustCompile(`^(<>|!=|>=?|<=?|==?)?\s*(.*)`)
+var regexpStableSuffix = regexp.MustCompile(`(?i)-stable$`)
+
+func (self *ConstraintGroup) parseConstraint(constraint string) ([]*Constraint, error) {
 
 	stabilityModifier := ""
 
-	result := RegFind(`(?i)^([^,\s]+?)@(stable|RC|beta|alpha|dev)$`, constraint)
+	result := regexpClauseStability.FindStringSubmatch(constraint)
 	if result != nil {
 		constraint = result[1]
 		if result[2] != "stable" {
@@ -131,15 +380,39 @@ func (self *ConstraintGroup) parseConstraint(constraint string) []*Constraint {
 		}
 	}
 
-	result = RegFind(`^[x*](\.[x*])*$`, constraint)
+	result = regexpAnyVersion.FindStringSubmatch(constraint)
 	if result != nil {
-		return make([]*Constraint, 0)
+		return make([]*Constraint, 0), nil
 	}
 
 	highVersion := ""
 	lowVersion := ""
 
-	result = RegFind(`(?i)^~(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?`+modifierRegex+`?$`, constraint)
+	result = regexpHyphenRange.FindStringSubmatch(constraint)
+	if result != nil {
+		lowVersion = Normalize(result[1])
+
+		// a full or pre-release upper bound is inclusive, a partial one allows
+		// anything up to the next release
+		if (result[11] != "" && result[12] != "") || result[14] != "" || result[16] != "" {
+			return []*Constraint{
+				{">=", lowVersion},
+				{"<=", Normalize(result[9])},
+			}, nil
+		}
+
+		position := 2
+		if result[11] == "" {
+			position = 1
+		}
+
+		return []*Constraint{
+			{">=", lowVersion},
+			{"<", bumpVersion(result[10:14], position) + "-dev"},
+		}, nil
+	}
+
+	result = regexpTilde.FindStringSubmatch(constraint)
 	if result != nil {
 		if len(result) > 4 && result[4] != "" {
 			last, _ := strconv.Atoi(result[3])
@@ -175,10 +448,32 @@ func (self *ConstraintGroup) parseConstraint(constraint string) []*Constraint {
 		return []*Constraint{
 			{">=", lowVersion},
 			{"<", highVersion},
+		}, nil
+	}
+
+	result = regexpCaret.FindStringSubmatch(constraint)
+	if result != nil {
+		// bump the first non-zero segment, or the last one given if all are zero
+		position := 3
+		if result[1] != "0" || result[2] == "" {
+			position = 1
+		} else if result[2] != "0" || result[3] == "" {
+			position = 2
 		}
+
+		low, _ := Parse(constraint[1:])
+		lowVersion = low.String()
+		if stabilityModifier != "" && low.Stability() == Stable && !low.IsDev() {
+			lowVersion = lowVersion + "-" + stabilityModifier
+		}
+
+		return []*Constraint{
+			{">=", lowVersion},
+			{"<", bumpVersion(result[1:5], position) + "-dev"},
+		}, nil
 	}
 
-	result = RegFind(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?\.[x*]$`, constraint)
+	result = regexpWildcard.FindStringSubmatch(constraint)
 	if result != nil {
 		if len(result) > 3 && result[3] != "" {
 			highVersion = result[1] + "." + result[2] + "." + result[3] + ".9999999"
@@ -203,7 +498,7 @@ func (self *ConstraintGroup) parseConstraint(constraint string) []*Constraint {
 		} else {
 			highVersion = result[1] + ".9999999.9999999.9999999"
 			if result[1] == "0" {
-				return []*Constraint{{"<", highVersion}}
+				return []*Constraint{{"<", highVersion}}, nil
 			} else {
 				last, _ := strconv.Atoi(result[1])
 				lowVersion = strconv.Itoa(last-1) + ".9999999.9999999.9999999"
@@ -213,34 +508,66 @@ func (self *ConstraintGroup) parseConstraint(constraint string) []*Constraint {
 		return []*Constraint{
 			{">", lowVersion},
 			{"<", highVersion},
-		}
+		}, nil
 	}
 
 	// match operators constraints
-	result = RegFind(`^(<>|!=|>=?|<=?|==?)?\s*(.*)`, constraint)
+	result = regexpOperator.FindStringSubmatch(constraint)
 	if result != nil {
-		version := Normalize(result[2])
+		version, _, err := normalizeStrict(result[2])
+		if err != nil {
+			version = Normalize(result[2])
+		}
+
+		// build metadata does not take part in precedence
+		version, _ = splitBuild(version)
 
 		if stabilityModifier != "" && parseStability(version) == "stable" {
 			version = version + "-" + stabilityModifier
 		} else if result[1] == "<" {
-			match := RegFind(`(?i)-stable$`, result[2])
-			if match == nil {
+			if !regexpStableSuffix.MatchString(result[2]) {
 				version = version + "-dev"
 			}
 		}
 
 		if len(result) > 1 && result[1] != "" {
-			return []*Constraint{{result[1], version}}
+			return []*Constraint{{result[1], version}}, err
 		} else {
-			return []*Constraint{{"=", version}}
+			return []*Constraint{{"=", version}}, err
+
+		}
+	}
+
+	return []*Constraint{{constraint, stabilityModifier}}, ErrInvalidConstraint
+}
+
+// increments the segment at position, 1 being the major, and resets the
+// following ones, returning a four segments version
+func bumpVersion(segments []string, position int) string {
+	version := ""
+	for i := 0; i < 4; i++ {
+		value := 0
+		if i < len(segments) {
+			value, _ = strconv.Atoi(segments[i])
+		}
+
+		if i == position-1 {
+			value++
+		} else if i >= position {
+			value = 0
+		}
 
+		if i > 0 {
+			version = version + "."
 		}
+		version = version + strconv.Itoa(value)
 	}
 
-	return []*Constraint{{constraint, stabilityModifier}}
+	return version
 }
 
+// Return the first match of pattern in subject and its submatches, nil if
+// there is no match
 func RegFind(pattern, subject string) []string {
 	reg := regexp.MustCompile(pattern)
 	matched := reg.FindAllStringSubmatch(subject, -1)
@@ -252,6 +579,7 @@ func RegFind(pattern, subject string) []string {
 	return nil
 }
 
+// Splits subject around the matches of pattern
 func RegSplit(pattern, subject string) []string {
 	reg := regexp.MustCompile(pattern)
 	indexes := reg.FindAllStringIndex(subject, -1)
@@ -1,6 +1,8 @@
 package version
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -98,8 +100,56 @@ func TestParseConstraintsTildeConstraints(t *testing.T) {
 	}
 }
 
+var caretConstraints = map[string][]*Constraint{
+	"^1":          []*Constraint{{">=", "1.0.0.0"}, {"<", "2.0.0.0-dev"}},
+	"^1.2":        []*Constraint{{">=", "1.2.0.0"}, {"<", "2.0.0.0-dev"}},
+	"^1.2.3":      []*Constraint{{">=", "1.2.3.0"}, {"<", "2.0.0.0-dev"}},
+	"^1.2.3.4":    []*Constraint{{">=", "1.2.3.4"}, {"<", "2.0.0.0-dev"}},
+	"^0":          []*Constraint{{">=", "0.0.0.0"}, {"<", "1.0.0.0-dev"}},
+	"^0.0":        []*Constraint{{">=", "0.0.0.0"}, {"<", "0.1.0.0-dev"}},
+	"^0.3":        []*Constraint{{">=", "0.3.0.0"}, {"<", "0.4.0.0-dev"}},
+	"^0.3.2":      []*Constraint{{">=", "0.3.2.0"}, {"<", "0.4.0.0-dev"}},
+	"^0.0.3":      []*Constraint{{">=", "0.0.3.0"}, {"<", "0.0.4.0-dev"}},
+	"^1.2-beta":   []*Constraint{{">=", "1.2.0.0-beta"}, {"<", "2.0.0.0-dev"}},
+	"^1.2.2-dev":  []*Constraint{{">=", "1.2.2.0-dev"}, {"<", "2.0.0.0-dev"}},
+	"^1.0@beta":   []*Constraint{{">=", "1.0.0.0-beta"}, {"<", "2.0.0.0-dev"}},
+	"^1.0-b2@dev": []*Constraint{{">=", "1.0.0.0-beta2"}, {"<", "2.0.0.0-dev"}},
+}
+
+func TestParseConstraintsCaretConstraints(t *testing.T) {
+	for in, out := range caretConstraints {
+		constraint := NewConstrainGroupFromString(in)
+		if x := constraint.GetConstraints(); len(x) != 2 || x[0].String() != out[0].String() || x[1].String() != out[1].String() {
+			t.Errorf("FAIL: parseConstraints(%v) = {%s}: want {%s}", in, x, out)
+		}
+	}
+}
+
+var hyphenConstraints = map[string][]*Constraint{
+	"1 - 2":             []*Constraint{{">=", "1.0.0.0"}, {"<", "3.0.0.0-dev"}},
+	"1.0 - 2.0":         []*Constraint{{">=", "1.0.0.0"}, {"<", "2.1.0.0-dev"}},
+	"1.2.3 - 2.3.4.5":   []*Constraint{{">=", "1.2.3.0"}, {"<=", "2.3.4.5"}},
+	"1.2 - 2.3.0":       []*Constraint{{">=", "1.2.0.0"}, {"<=", "2.3.0.0"}},
+	"1.2-beta - 2.3":    []*Constraint{{">=", "1.2.0.0-beta"}, {"<", "2.4.0.0-dev"}},
+	"1.2 - 2.3-beta":    []*Constraint{{">=", "1.2.0.0"}, {"<=", "2.3.0.0-beta"}},
+	"1.2 - 2.3-dev":     []*Constraint{{">=", "1.2.0.0"}, {"<=", "2.3.0.0-dev"}},
+	"v1.2.3 -   v2.3.4": []*Constraint{{">=", "1.2.3.0"}, {"<=", "2.3.4.0"}},
+}
+
+func TestParseConstraintsHyphenConstraints(t *testing.T) {
+	for in, out := range hyphenConstraints {
+		constraint := NewConstrainGroupFromString(in)
+		if x := constraint.GetConstraints(); len(x) != 2 || x[0].String() != out[0].String() || x[1].String() != out[1].String() {
+			t.Errorf("FAIL: parseConstraints(%v) = {%s}: want {%s}", in, x, out)
+		}
+	}
+}
+
 var multiConstraints = map[string][]*Constraint{
 	">2.0,<=3.0":            []*Constraint{{">", "2.0.0.0"}, {"<=", "3.0.0.0"}},
+	">2.0 <=3.0":            []*Constraint{{">", "2.0.0.0"}, {"<=", "3.0.0.0"}},
+	">2.0, <=3.0":           []*Constraint{{">", "2.0.0.0"}, {"<=", "3.0.0.0"}},
+	"> 2.0   <= 3.0":        []*Constraint{{">", "2.0.0.0"}, {"<=", "3.0.0.0"}},
 	">2.0@stable,<=3.0@dev": []*Constraint{{">", "2.0.0.0"}, {"<=", "3.0.0.0-dev"}},
 }
 
@@ -112,8 +162,88 @@ func TestParseConstraintsMultiConstraints(t *testing.T) {
 	}
 }
 
+var clauseValues = map[string][]clause{
+	">=1.0 <2.0":                {{">=1.0", 0}, {"<2.0", 6}},
+	">= 1.0, < 2.0":             {{">= 1.0", 0}, {"< 2.0", 8}},
+	"1.0 - 2.0 !=1.5":           {{"1.0 - 2.0", 0}, {"!=1.5", 10}},
+	"dev-master as 1.0.0 >=0.9": {{"dev-master as 1.0.0", 0}, {">=0.9", 20}},
+	" ~1.2 ,  ^1.3 ":            {{"~1.2", 1}, {"^1.3", 9}},
+	">=, 1.0":                   {{">=", 0}, {"1.0", 4}},
+	"":                          {},
+}
+
+func TestSplitClauses(t *testing.T) {
+	for in, out := range clauseValues {
+		if x := splitClauses(in); !reflect.DeepEqual(x, out) {
+			t.Errorf("FAIL: splitClauses(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestParseConstraintGroup(t *testing.T) {
+	valid := []map[string][]*Constraint{
+		anyConstraints, simpleConstraints, wildcardConstraints, tildeConstraints,
+		caretConstraints, hyphenConstraints, multiConstraints,
+	}
+
+	for _, constraints := range valid {
+		for in := range constraints {
+			group, err := ParseConstraintGroup(in)
+			if err != nil {
+				t.Errorf("FAIL: ParseConstraintGroup(%v) returned error %v", in, err)
+				continue
+			}
+
+			want := NewConstrainGroupFromString(in).GetConstraints()
+			if x := group.GetConstraints(); !reflect.DeepEqual(x, want) {
+				t.Errorf("FAIL: ParseConstraintGroup(%v) = {%s}: want {%s}", in, x, want)
+			}
+		}
+	}
+
+	for in := range orConstraints {
+		if _, err := ParseConstraintGroup(in); err != nil {
+			t.Errorf("FAIL: ParseConstraintGroup(%v) returned error %v", in, err)
+		}
+	}
+}
+
+var invalidConstraints = map[string]ConstraintError{
+	"":                   {Clause: "", Offset: 0, Err: ErrEmptyConstraint},
+	"foo":                {Clause: "foo", Offset: 0, Err: ErrInvalidVersion},
+	">=1.0, <2.0-foo":    {Clause: "<2.0-foo", Offset: 7, Err: ErrUnknownModifier},
+	">=1.0 <1.2.3.4.5.6": {Clause: "<1.2.3.4.5.6", Offset: 6, Err: ErrTooManySegments},
+	"~1.2 || ^1.x":       {Clause: "^1.x", Offset: 8, Err: ErrInvalidVersion},
+	"~1.2 ||":            {Clause: "", Offset: 7, Err: ErrEmptyConstraint},
+	">=":                 {Clause: ">=", Offset: 0, Err: ErrEmptyVersion},
+	"1.0#abcd123":        {Clause: "1.0#abcd123", Offset: 0, Err: ErrUnknownModifier},
+}
+
+func TestParseConstraintGroupErrors(t *testing.T) {
+	for in, out := range invalidConstraints {
+		group, err := ParseConstraintGroup(in)
+		if group != nil {
+			t.Errorf("FAIL: ParseConstraintGroup(%v) = %v: want nil", in, group)
+		}
+
+		x, ok := err.(*ConstraintError)
+		if !ok {
+			t.Errorf("FAIL: ParseConstraintGroup(%v) error is %T: want *ConstraintError", in, err)
+			continue
+		}
+
+		if x.Constraint != in || x.Clause != out.Clause || x.Offset != out.Offset || !errors.Is(x, out.Err) {
+			t.Errorf("FAIL: ParseConstraintGroup(%v) = %+v: want %+v", in, x, out)
+		}
+	}
+}
+
 var miscConstraints = map[string]bool{
 	"*|1.0":                   true,
+	"1.0+5|1.0.0+6":           true,
+	"<1.0+5|0.9+6":            true,
+	"<1.0+5|1.0.0+4":          false,
+	">=1.0|1.0.0+4":           true,
 	">2.0,<=3.0|2.5.0beta":    true,
 	">2.0,<=3.0|3.5.0beta":    false,
 	">=2.2.3,<2.4-dev|2.3.3":  true,
@@ -126,6 +256,19 @@ var miscConstraints = map[string]bool{
 	">2.0.5|2.0.9":            true,
 	"<2.0.5|2.0.9":            false,
 	">=dev-master|dev-master": true,
+	"^1.2.3|1.9.0":            true,
+	"^1.2.3|2.0.0":            false,
+	"^1.2.3|2.0.0-beta":       false,
+	"^0.3|0.3.9":              true,
+	"^0.3|0.4.0":              false,
+	"^1.0|1.0.0-beta2":        false,
+	"^1.0@beta|1.0.0-beta2":   true,
+	"1.0 - 2.0|2.0.5":         true,
+	"1.0 - 2.0|2.1.0":         false,
+	"1.0 - 2.0.0|2.0.5":       false,
+	">=1.0 <1.1|1.0.5":        true,
+	">=1.0 <1.1|1.1.0":        false,
+	">= 1.0 < 1.1|1.0.5":      true,
 }
 
 func TestMatch(t *testing.T) {
@@ -139,6 +282,68 @@ func TestMatch(t *testing.T) {
 	}
 }
 
+var orConstraints = map[string]int{
+	"~1.2 || ^2.0":           2,
+	"1.0.*|2.0.*":            2,
+	">=1.0,<1.1 || >=1.2":    2,
+	"1.0 || 2.0 || 3.0@dev":  3,
+	"^1.0|| ~2.0 |3.0":       3,
+	">=1.0,<2.0":             0,
+	"dev-master || 1.0.*":    2,
+	"1.0.x-dev#abc || 2.0.*": 2,
+}
+
+func TestParseConstraintsOrConstraints(t *testing.T) {
+	for in, out := range orConstraints {
+		constraint := NewConstrainGroupFromString(in)
+		if x := constraint.GetAlternatives(); len(x) != out {
+			t.Errorf("FAIL: parseConstraints(%v) = %v alternatives: want %v", in, len(x), out)
+		}
+	}
+}
+
+var orMatches = map[string]bool{
+	"~1.2 || ^2.0#1.5.0":             true,
+	"~1.2 || ^2.0#2.5.0":             true,
+	"~1.2 || ^2.0#3.0.0":             false,
+	"~1.2 || ^2.0#1.1.0":             false,
+	"1.0.*|2.0.*#2.0.3":              true,
+	"1.0.*|2.0.*#1.1.0":              false,
+	">=1.0,<1.1 || >=1.2#1.0.5":      true,
+	">=1.0,<1.1 || >=1.2#1.1.5":      false,
+	">=1.0,<1.1 || >=1.2#1.3":        true,
+	"* || 1.0#5.0":                   true,
+	"dev-master || 1.0.*#1.0.1":      true,
+	"dev-master || 1.0.*#dev-master": true,
+}
+
+func TestMatchOr(t *testing.T) {
+	for in, out := range orMatches {
+		tmp := strings.Split(in, "#")
+
+		constraint := NewConstrainGroupFromString(tmp[0])
+		if x := constraint.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestAddAlternative(t *testing.T) {
+	group := NewConstrainGroup()
+	group.AddConstraint(NewConstrain(">=", "1.0.0"))
+	group.AddAlternative(NewConstrainGroupFromString("<1.1"), NewConstrainGroupFromString(">=2.0"))
+
+	if x := group.GetAlternatives(); len(x) != 2 {
+		t.Errorf("FAIL: GetAlternatives() = %v: want 2 alternatives", x)
+	}
+
+	for in, out := range map[string]bool{"0.9": false, "1.0.5": true, "1.5": false, "2.1": true} {
+		if x := group.Match(in); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
 func TestAddConstraint(t *testing.T) {
 	group := NewConstrainGroup()
 	group.AddConstraint(NewConstrain("=", "1.0.0"))
@@ -152,3 +357,15 @@ func TestAddConstraint(t *testing.T) {
 		t.Errorf("FAIL: AddConstraintVersion() = {%s}: want {%s}", x, "1.0.0")
 	}
 }
+
+func BenchmarkConstraintGroupMatch(b *testing.B) {
+	versions := benchmarkVersions(50000)
+	group := NewConstrainGroupFromString(">=1.2 <5.0-dev, !=3.1.4 || ^6.0")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, version := range versions {
+			group.Match(version)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// qualifiers known by Maven, in their order, any other one coming after them
+// in alphabetical order
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// MavenVersion is a version of a Maven artifact, ordered like Maven's
+// ComparableVersion
+//
+// Versions are split in lists of numbers and qualifiers, - starting a sub
+// list, with the qualifiers ordered alpha < beta < milestone < rc < snapshot
+// < release < sp, see
+// https://maven.apache.org/pom.html#version-order-specification
+type MavenVersion struct {
+	original string
+	items    *mavenList
+}
+
+// Maven is the scheme of Maven artifacts, registered as "maven"
+//
+// Constraints are version ranges, like [1.0,2.0) or (,1.0],[1.2,)
+var Maven Scheme = mavenScheme{}
+
+func init() {
+	RegisterScheme("maven", Maven)
+}
+
+// Parses a Maven version
+//
+// Unlike Maven, which takes any string as a version, versions must have a
+// digit and only letters, digits and the separators . - _ +, others being
+// reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseMaven("1.0.0.Final")
+//     v.String()
+//     Returns: "1"
+func ParseMaven(version string) (MavenVersion, error) {
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return MavenVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if strings.IndexFunc(trimmed, isNotMavenChar) >= 0 {
+		return MavenVersion{}, &ParseError{version, ErrInvalidVersion, "invalid character"}
+	}
+
+	if strings.IndexAny(trimmed, "0123456789") < 0 {
+		return MavenVersion{}, &ParseError{version, ErrInvalidVersion, "no digit"}
+	}
+
+	return MavenVersion{version, parseMavenItems(strings.ToLower(trimmed))}, nil
+}
+
+func isNotMavenChar(char rune) bool {
+	return !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+		strings.ContainsRune(".-_+", char))
+}
+
+func parseMavenItems(version string) *mavenList {
+	root := &mavenList{}
+	list := root
+	stack := []*mavenList{root}
+
+	// starts a sub list, holding what follows
+	sublist := func() {
+		child := &mavenList{}
+		*list = append(*list, child)
+		list = child
+		stack = append(stack, child)
+	}
+
+	digit := false
+	start := 0
+	for i := 0; i < len(version); i++ {
+		char := version[i]
+
+		switch {
+		case char == '.' || char == '-':
+			if i == start {
+				*list = append(*list, mavenInt("0"))
+			} else {
+				*list = append(*list, parseMavenItem(digit, version[start:i]))
+			}
+			start = i + 1
+
+			if char == '-' {
+				sublist()
+			}
+		case isDigit(char):
+			// a qualifier followed by a number, like rc1
+			if !digit && i > start {
+				*list = append(*list, newMavenString(version[start:i], true))
+				start = i
+				sublist()
+			}
+			digit = true
+		default:
+			// a number followed by a qualifier, like 1rc
+			if digit && i > start {
+				*list = append(*list, parseMavenItem(true, version[start:i]))
+				start = i
+				sublist()
+			}
+			digit = false
+		}
+	}
+
+	if len(version) > start {
+		*list = append(*list, parseMavenItem(digit, version[start:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+
+	return root
+}
+
+func parseMavenItem(digit bool, value string) mavenItem {
+	if digit {
+		value = strings.TrimLeft(value, "0")
+		if value == "" {
+			value = "0"
+		}
+
+		return mavenInt(value)
+	}
+
+	return newMavenString(value, false)
+}
+
+// a, b and m followed by a number are alpha, beta and milestone
+func newMavenString(value string, followedByDigit bool) mavenString {
+	if followedByDigit && len(value) == 1 {
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+
+	if alias, ok := mavenAliases[value]; ok {
+		value = alias
+	}
+
+	return mavenString(value)
+}
+
+// Compares two Maven versions, like Maven's ComparableVersion
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareMaven("1.0-SNAPSHOT", "1.0")
+//     Returns: -1
+//
+//     version.CompareMaven("1.0.Final", "1-ga")
+//     Returns: 0
+func CompareMaven(version1, version2 string) int {
+	v1, err1 := ParseMaven(version1)
+	v2, err2 := ParseMaven(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the string given to ParseMaven
+func (self MavenVersion) Original() string {
+	return self.original
+}
+
+// Return true for snapshots, versions ending with -SNAPSHOT
+func (self MavenVersion) IsSnapshot() bool {
+	return strings.HasSuffix(strings.ToUpper(strings.TrimSpace(self.original)), "SNAPSHOT")
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self MavenVersion) Compare(other MavenVersion) int {
+	// empty versions, that failed to parse, are lower than any other
+	switch {
+	case self.items == nil && other.items == nil:
+		return 0
+	case self.items == nil:
+		return -1
+	case other.items == nil:
+		return 1
+	}
+
+	return self.items.compare(other.items)
+}
+
+// Return true if both versions are equal
+func (self MavenVersion) Equal(other MavenVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self MavenVersion) Less(other MavenVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the canonical form of the version, the one two equal versions share
+func (self MavenVersion) String() string {
+	if self.items == nil {
+		return ""
+	}
+
+	return self.items.String()
+}
+
+// item of a Maven version, compared to nil when the other version has no
+// more items
+type mavenItem interface {
+	compare(other mavenItem) int
+	isNull() bool
+	String() string
+}
+
+// number without leading zeros
+type mavenInt string
+
+// qualifier, after aliases
+type mavenString string
+
+type mavenList []mavenItem
+
+func (self mavenInt) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		if self == "0" {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		if cmp := compareInt(len(self), len(other)); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(string(self), string(other))
+	}
+
+	// a number is newer than qualifiers and sub lists
+	return 1
+}
+
+func (self mavenInt) isNull() bool {
+	return self == "0"
+}
+
+func (self mavenInt) String() string {
+	return string(self)
+}
+
+func (self mavenString) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		// a qualifier is compared to the release, 1-rc < 1 < 1-sp
+		return strings.Compare(self.comparable(), mavenString("").comparable())
+	case mavenString:
+		return strings.Compare(self.comparable(), other.comparable())
+	}
+
+	return -1
+}
+
+// known qualifiers are ranked by their index, unknown ones coming after them
+func (self mavenString) comparable() string {
+	for i, qualifier := range mavenQualifiers {
+		if string(self) == qualifier {
+			return strconv.Itoa(i)
+		}
+	}
+
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + string(self)
+}
+
+func (self mavenString) isNull() bool {
+	return self == ""
+}
+
+func (self mavenString) String() string {
+	return string(self)
+}
+
+// removes the trailing null items, 1.0.0 being 1
+func (self *mavenList) normalize() {
+	for i := len(*self) - 1; i >= 0; i-- {
+		item := (*self)[i]
+		if item.isNull() {
+			*self = append((*self)[:i], (*self)[i+1:]...)
+		} else if _, ok := item.(*mavenList); !ok {
+			break
+		}
+	}
+}
+
+func (self *mavenList) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		// every item is compared to the release, not only the first one,
+		// 1-0.alpha < 1 since MNG-6964
+		for _, item := range *self {
+			if cmp := item.compare(nil); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case *mavenList:
+		for i := 0; i < len(*self) || i < len(*other); i++ {
+			var left, right mavenItem
+			if i < len(*self) {
+				left = (*self)[i]
+			}
+			if i < len(*other) {
+				right = (*other)[i]
+			}
+
+			var cmp int
+			if left == nil {
+				cmp = -right.compare(nil)
+			} else {
+				cmp = left.compare(right)
+			}
+
+			if cmp != 0 {
+				return cmp
+			}
+		}
+	}
+
+	return 0
+}
+
+func (self *mavenList) isNull() bool {
+	return len(*self) == 0
+}
+
+func (self *mavenList) String() string {
+	var builder strings.Builder
+	for i, item := range *self {
+		if i > 0 {
+			if _, ok := item.(*mavenList); ok {
+				builder.WriteByte('-')
+			} else {
+				builder.WriteByte('.')
+			}
+		}
+		builder.WriteString(item.String())
+	}
+
+	return builder.String()
+}
+
+// Parses a Maven version range, like [1.0,2.0) or (,1.0],[1.2,)
+//
+// Each bracketed restriction is an alternative of the returned group: [
+// and ] are inclusive bounds, ( and ) exclusive ones, a missing bound is
+// unbounded and [1.0] matches 1.0 only. Like Maven, a bare version is a soft
+// requirement that matches any version. Malformed ranges are reported as a
+// *ConstraintError.
+//
+// Usage
+//     c, _ := version.ParseMavenRange("(,1.0],[1.2,)")
+//     c.Match("1.1")
+//     Returns: false
+func ParseMavenRange(rng string) (*ConstraintGroup, error) {
+	group := &ConstraintGroup{scheme: Maven}
+
+	rest := strings.TrimSpace(rng)
+	if rest == "" {
+		return nil, &ConstraintError{rng, rng, 0, ErrEmptyConstraint}
+	}
+
+	var previous *ConstraintGroup
+	for strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, "(") {
+		offset := len(rng) - len(strings.TrimLeft(rng, " \t")) + len(strings.TrimSpace(rng)) - len(rest)
+
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return nil, &ConstraintError{rng, rest, offset, ErrInvalidConstraint}
+		}
+
+		restriction, err := parseMavenRestriction(rest[:end+1])
+		if err != nil {
+			return nil, &ConstraintError{rng, rest[:end+1], offset, err}
+		}
+
+		if previous != nil && !mavenOrdered(previous, restriction) {
+			return nil, &ConstraintError{rng, rest[:end+1], offset, ErrInvalidConstraint}
+		}
+
+		group.AddAlternative(restriction)
+		previous = restriction
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+		}
+	}
+
+	if rest != "" {
+		// only fully bracketed sets are allowed besides a soft requirement
+		if len(group.alternatives) > 0 {
+			return nil, &ConstraintError{rng, rest, len(strings.TrimRight(rng, " \t")) - len(rest), ErrInvalidConstraint}
+		}
+
+		if strings.ContainsAny(rest, "[](),") {
+			return nil, &ConstraintError{rng, rest, 0, ErrInvalidConstraint}
+		}
+	}
+
+	return group, nil
+}
+
+// parses a bracketed restriction into a group of bounds
+func parseMavenRestriction(restriction string) (*ConstraintGroup, error) {
+	group := &ConstraintGroup{scheme: Maven}
+
+	lowInclusive := restriction[0] == '['
+	highInclusive := restriction[len(restriction)-1] == ']'
+	inner := strings.TrimSpace(restriction[1 : len(restriction)-1])
+
+	low, high, ranged := strings.Cut(inner, ",")
+	if !ranged {
+		// a single version, [1.0]
+		if !lowInclusive || !highInclusive || inner == "" {
+			return nil, ErrInvalidConstraint
+		}
+
+		group.AddConstraint(&Constraint{"=", inner})
+
+		return group, nil
+	}
+
+	low, high = strings.TrimSpace(low), strings.TrimSpace(high)
+	if strings.Contains(high, ",") {
+		return nil, ErrInvalidConstraint
+	}
+
+	if low != "" {
+		operator := ">"
+		if lowInclusive {
+			operator = ">="
+		}
+		group.AddConstraint(&Constraint{operator, low})
+	}
+
+	if high != "" {
+		operator := "<"
+		if highInclusive {
+			operator = "<="
+		}
+		group.AddConstraint(&Constraint{operator, high})
+	}
+
+	// identical bounds are only allowed when both are inclusive
+	if low != "" && high != "" {
+		cmp := CompareMaven(low, high)
+		if cmp > 0 || cmp == 0 && !(lowInclusive && highInclusive) {
+			return nil, ErrInvalidConstraint
+		}
+	}
+
+	return group, nil
+}
+
+// tells if restriction comes after previous, without overlapping it
+func mavenOrdered(previous, restriction *ConstraintGroup) bool {
+	var upper, lower *Constraint
+	for _, constraint := range previous.constraints {
+		if constraint.operator != ">" && constraint.operator != ">=" {
+			upper = constraint
+		}
+	}
+
+	for _, constraint := range restriction.constraints {
+		if constraint.operator != "<" && constraint.operator != "<=" {
+			lower = constraint
+			break
+		}
+	}
+
+	if upper == nil || lower == nil {
+		return false
+	}
+
+	cmp := CompareMaven(upper.version, lower.version)
+
+	return cmp < 0 || cmp == 0 && (upper.operator == "<" || lower.operator == ">")
+}
+
+type mavenScheme struct{}
+
+func (mavenScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseMaven(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (mavenScheme) Compare(version1, version2 string) int {
+	return CompareMaven(version1, version2)
+}
+
+func (mavenScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(MavenVersion).Compare(v2.(MavenVersion))
+}
+
+func (mavenScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseMavenRange(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (mavenScheme) Format(version string) (string, error) {
+	v, err := ParseMaven(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
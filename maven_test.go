@@ -0,0 +1,189 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var mavenValues = map[string]string{
+	"1.0.0":        "1",
+	"1.0.0.Final":  "1",
+	"1-ga":         "1",
+	"1.0-SNAPSHOT": "1-snapshot",
+	"1.0-alpha1":   "1-alpha-1",
+	"1.0-a1":       "1-alpha-1",
+	"1.0-m2":       "1-milestone-2",
+	"1.0.CR1":      "1.0.rc-1",
+	"2.0.1-sp1":    "2.0.1-sp-1",
+	"1.2.0-beta-0": "1.2-beta",
+	"01.002":       "1.2",
+	"1-1.foo-bar1": "1-1.foo-bar-1",
+	"1.0RC1":       "1-rc-1",
+	"1.a":          "1.a",
+}
+
+func TestParseMaven(t *testing.T) {
+	for in, out := range mavenValues {
+		v, err := ParseMaven(in)
+		if err != nil || v.String() != out || v.Original() != in {
+			t.Errorf("FAIL: ParseMaven(%v) = %v, %v: want %v", in, v, err, out)
+		}
+	}
+
+	for _, in := range []string{"", " "} {
+		if v, err := ParseMaven(in); !errors.Is(err, ErrEmptyVersion) {
+			t.Errorf("FAIL: ParseMaven(%v) = %v, %v: want error %v", in, v, err, ErrEmptyVersion)
+		}
+	}
+
+	for _, in := range []string{"garbage", "1.0 beta", "1.0/1", "-"} {
+		if v, err := ParseMaven(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseMaven(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// in the order of Maven's ComparableVersionTest
+var mavenOrder = []string{
+	"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123",
+	"1-m2", "1-m11", "1-rc", "1-cr2", "1-rc123", "1-SNAPSHOT", "1", "1-sp",
+	"1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot", "1-1",
+	"1-2", "1-123",
+}
+
+var compareMavenValues = map[string]int{
+	"1|1.0":                  0,
+	"1|1.0.0":                0,
+	"1.0|1.0.0.0":            0,
+	"1ga|1-ga":               0,
+	"1.0.Final|1-ga":         0,
+	"1-release|1":            0,
+	"1.0-SNAPSHOT|1.0":       -1,
+	"1.0-SNAPSHOT|1.0-rc1":   1,
+	"1-a1|1-alpha-1":         0,
+	"1-b1|1-beta-1":          0,
+	"1-m1|1-milestone-1":     0,
+	"1-cr1|1-rc1":            0,
+	"1A|1a":                  0,
+	"1.0-sp1|1.0":            1,
+	"1.0.1|1.0-sp1":          1,
+	"1.0-foo|1.0-sp":         1,
+	"2.0.0.RELEASE|2.0.0":    0,
+	"1.10|1.9":               1,
+	"1.0.0-x|1.0.0-y":        -1,
+	"99999999999999999999|1": 1,
+	"1-0.1|1":                1,
+	"1-0.alpha|1":            -1,
+	"1-0.SNAPSHOT|1":         -1,
+	"1.0.Final|1.0.Final.1":  -1,
+	"|1":                     -1,
+	"|":                      0,
+	"garbage|1":              -1,
+}
+
+func TestCompareMaven(t *testing.T) {
+	for i := 0; i < len(mavenOrder); i++ {
+		for j := 0; j < len(mavenOrder); j++ {
+			out := compareInt(i, j)
+			if x := CompareMaven(mavenOrder[i], mavenOrder[j]); x != out {
+				t.Errorf("FAIL: CompareMaven(%v|%v) = %v: want %v", mavenOrder[i], mavenOrder[j], x, out)
+			}
+		}
+	}
+
+	for in, out := range compareMavenValues {
+		v := strings.Split(in, "|")
+		if x := CompareMaven(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareMaven(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareMaven(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareMaven(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := Maven.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: Maven.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestMavenIsSnapshot(t *testing.T) {
+	for in, out := range map[string]bool{"1.0-SNAPSHOT": true, "1.0-snapshot": true, "1.0": false, "1.0-SNAPSHOT-1": false} {
+		v, _ := ParseMaven(in)
+		if x := v.IsSnapshot(); x != out {
+			t.Errorf("FAIL: IsSnapshot(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var mavenRanges = map[string]bool{
+	"[1.0,2.0)|1.0":           true,
+	"[1.0,2.0)|1.5.3":         true,
+	"[1.0,2.0)|2.0":           false,
+	"[1.0,2.0)|2.0-SNAPSHOT":  true,
+	"[1.0,2.0)|1.0-SNAPSHOT":  false,
+	"(1.0,2.0]|1.0":           false,
+	"(1.0,2.0]|2.0.0.Final":   true,
+	"[1.0]|1.0.0":             true,
+	"[1.0]|1.0.1":             false,
+	"[1.0,1.0]|1.0":           true,
+	"(,1.0]|0.1":              true,
+	"(,1.0]|1.0.1":            false,
+	"[1.2,)|99":               true,
+	"(,1.0],[1.2,)|1.0":       true,
+	"(,1.0],[1.2,)|1.1":       false,
+	"(,1.0],[1.2,)|1.2":       true,
+	"(,1.0), (1.0,)|1.0":      false,
+	"(,1.0), (1.0,)|1.0.1":    true,
+	"[1.0,1.5), [2.0]|2.0-ga": true,
+	"[ 1.0 , 2.0 )|1.9":       true,
+	"1.0|0.1":                 true,
+	"1.0|5.0":                 true,
+	"(,3.0)|garbage":          false,
+	"(,3.0)|":                 false,
+}
+
+func TestParseMavenRange(t *testing.T) {
+	for in, out := range mavenRanges {
+		tmp := strings.Split(in, "|")
+
+		c, err := ParseMavenRange(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseMavenRange(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := c.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	errs := map[string]error{
+		"":                 ErrEmptyConstraint,
+		"[1.0":             ErrInvalidConstraint,
+		"(1.0)":            ErrInvalidConstraint,
+		"[]":               ErrInvalidConstraint,
+		"[2.0,1.0]":        ErrInvalidConstraint,
+		"[1.0,1.0)":        ErrInvalidConstraint,
+		"(1.0,1.0]":        ErrInvalidConstraint,
+		"(1.0,1.0)":        ErrInvalidConstraint,
+		"[1.0,2.0,3.0]":    ErrInvalidConstraint,
+		"[1.0,2.0],1.5":    ErrInvalidConstraint,
+		"[1.0,2.0],[1.5,)": ErrInvalidConstraint,
+		"[1.5,),[1.0,2.0]": ErrInvalidConstraint,
+		"[1.0],[1.0]":      ErrInvalidConstraint,
+		"1.0,2.0":          ErrInvalidConstraint,
+	}
+
+	for in, out := range errs {
+		if c, err := Maven.ParseConstraint(in); c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: Maven.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+
+	var e *ConstraintError
+	if _, err := ParseMavenRange("[1.0,2.0), (3.0"); !errors.As(err, &e) || e.Offset != 11 {
+		t.Errorf("FAIL: ParseMavenRange([1.0,2.0), (3.0) = %v: want offset 11", err)
+	}
+}
@@ -2,65 +2,172 @@ package version
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 var modifierRegex = `[._-]?(?:(stable|beta|b|RC|alpha|a|patch|pl|p)(?:[.-]?(\d+))?)?([.-]?dev)?`
 
-var regexpMasterLikeBranches = regexp.MustCompile(`^(?:dev-)?(?:master|trunk|default)$`)
+var regexpVersionHead = regexp.MustCompile(`(?i)^v?(\d+)((?:\.\d+)*)(.*)$`)
+var regexpModifier = regexp.MustCompile(`(?i)^` + modifierRegex + `$`)
+
+var regexpAlias = regexp.MustCompile(`^([^,\s]+) +as +([^,\s]+)$`)
+var regexpClassical = regexp.MustCompile(`(?i)^v?(\d{1,3})(\.\d+)?(\.\d+)?(\.\d+)?` + modifierRegex + `$`)
+var regexpDateBased = regexp.MustCompile(`(?i)^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3})?)` + modifierRegex + `$`)
+var regexpNonDigit = regexp.MustCompile(`\D`)
+var regexpDevSuffix = regexp.MustCompile(`(?i)(.*?)[.-]?dev$`)
+
 var regexpBranchNormalize = regexp.MustCompile(`(?i)^v?(\d+)(\.(?:\d+|[x*]))?(\.(?:\d+|[x*]))?(\.(?:\d+|[x*]))?$`)
 
 // Normalizes a version string to be able to perform comparisons on it
 //
+// Build metadata, like +20130313 in 1.0.0+20130313, is kept at the end of
+// the normalized version.
+//
 // Example:
 //     version.Normalize("10.4.13-b")
 //     Returns: 10.4.13.0-beta
 //
 func Normalize(version string) string {
+	normalized, _ := normalize(version)
+
+	return normalized
+}
+
+// Normalizes a version string, failing on anything that is not a valid
+// Composer-style version
+//
+// Where Normalize returns unrecognized versions unchanged, NormalizeStrict
+// returns a *ParseError wrapping ErrEmptyVersion, ErrUnknownModifier,
+// ErrTooManySegments, ErrBadDate or ErrInvalidVersion.
+//
+// Example:
+//     version.NormalizeStrict("1.2.3.4.5")
+//     Returns: "", version: too many version segments: "1.2.3.4.5" (5 segments, at most 4 allowed)
+//
+func NormalizeStrict(version string) (string, error) {
+	normalized, _, err := normalizeStrict(version)
+
+	return normalized, err
+}
+
+func normalizeStrict(version string) (string, Kind, error) {
+	if strings.TrimSpace(version) == "" {
+		return "", KindUnknown, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	normalized, kind := normalize(version)
+	if kind == KindUnknown {
+		return "", kind, diagnoseVersion(version)
+	}
+
+	return normalized, kind, nil
+}
+
+// tells why a version not recognized by normalize is invalid
+func diagnoseVersion(version string) error {
+	result := regexpVersionHead.FindStringSubmatch(version)
+	if result == nil {
+		return &ParseError{version, ErrInvalidVersion, "does not start with a number"}
+	}
+
+	if len(result[1]) > 3 {
+		return &ParseError{version, ErrBadDate, ""}
+	}
+
+	segments := strings.Count(result[2], ".") + 1
+	if segments > 4 {
+		return &ParseError{version, ErrTooManySegments, strconv.Itoa(segments) + " segments, at most 4 allowed"}
+	}
+
+	if !regexpModifier.MatchString(result[3]) {
+		return &ParseError{version, ErrUnknownModifier, strings.TrimLeft(result[3], "._-")}
+	}
+
+	return &ParseError{version, ErrInvalidVersion, ""}
+}
+
+func normalize(version string) (string, Kind) {
+	// build metadata is kept aside and given back as is
+	if release, build := splitBuild(version); build != "" {
+		normalized, kind := normalizeRelease(release)
+		if kind == KindClassic || kind == KindDate {
+			return normalized + "+" + build, kind
+		}
+	}
+
+	return normalizeRelease(version)
+}
+
+// Splits the build metadata, a + followed by dot separated alphanumerics and
+// hyphens, from the end of a version
+//
+// Example:
+//     splitBuild("1.0.0-beta+exp.sha.5114f85")
+//     Returns: "1.0.0-beta", "exp.sha.5114f85"
+//
+func splitBuild(version string) (string, string) {
+	i := strings.IndexByte(version, '+')
+	if i < 0 || i == len(version)-1 {
+		return version, ""
+	}
+
+	for j := i + 1; j < len(version); j++ {
+		char := version[j]
+		if !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char == '-' || char == '.') {
+			return version, ""
+		}
+	}
+
+	return version[:i], version[i+1:]
+}
+
+func normalizeRelease(version string) (string, Kind) {
 
 	// ignore aliases and just assume the alias is required instead of the source
-	result := RegFind(`^([^,\s]+) +as +([^,\s]+)$`, version)
-	if result != nil {
-		version = result[1]
+	var result []string
+	if strings.Contains(version, " as ") {
+		result = regexpAlias.FindStringSubmatch(version)
+		if result != nil {
+			version = result[1]
+		}
 	}
 
 	// match master-like branches
-	if regexpMasterLikeBranches.MatchString(strings.ToLower(version)) {
-		return "9999999-dev"
+	lower := strings.ToLower(version)
+	switch strings.TrimPrefix(lower, "dev-") {
+	case "master", "trunk", "default":
+		return "9999999-dev", KindBranch
 	}
 
-	if strings.HasPrefix(strings.ToLower(version), "dev-") {
-		return "dev-" + version[4:len(version)]
+	if strings.HasPrefix(lower, "dev-") {
+		return "dev-" + version[4:len(version)], KindBranch
 	}
 
 	index := 0
+	kind := KindUnknown
 
 	// match classical versioning
-	result = RegFind(`(?i)^v?(\d{1,3})(\.\d+)?(\.\d+)?(\.\d+)?`+modifierRegex+`$`, version)
+	result = scanClassical(version)
 	if result != nil {
-		version = ""
-		for _, val := range result[1:5] {
-			if val != "" {
-				version = version + val
-			} else {
-				version = version + ".0"
-			}
-		}
+		version = result[1] + orDefault(result[2], ".0") + orDefault(result[3], ".0") + orDefault(result[4], ".0")
 
 		index = 5
-	} else {
+		kind = KindClassic
+	} else if hasDatePrefix(version) {
 		// match date-based versioning
-		result = RegFind(`(?i)^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3})?)`+modifierRegex+`$`, version)
+		result = regexpDateBased.FindStringSubmatch(version)
 		if result != nil {
-			version = regexp.MustCompile(`\D`).ReplaceAllString(result[1], "-")
+			version = regexpNonDigit.ReplaceAllString(result[1], "-")
 			index = 2
+			kind = KindDate
 		}
 	}
 
 	if index != 0 {
 		if result[index] != "" {
 			if result[index] == "stable" {
-				return version
+				return version, kind
 			}
 
 			version = version + "-" + expandStability(result[index])
@@ -73,15 +180,19 @@ func Normalize(version string) string {
 			version = version + "-dev"
 		}
 
-		return version
+		return version, kind
+	}
+
+	if len(version) < 3 || !strings.EqualFold(version[len(version)-3:], "dev") {
+		return version, KindUnknown
 	}
 
-	result = RegFind(`(?i)(.*?)[.-]?dev$`, version)
+	result = regexpDevSuffix.FindStringSubmatch(version)
 	if result != nil {
-		return normalizeBranch(result[1])
+		return normalizeBranch(result[1]), KindBranch
 	}
 
-	return version
+	return version, KindUnknown
 }
 
 func normalizeBranch(name string) string {
@@ -114,3 +225,108 @@ func normalizeBranch(name string) string {
 
 	return "dev-" + name
 }
+
+var stabilityWords = []string{"stable", "beta", "b", "RC", "alpha", "a", "patch", "pl", "p"}
+
+// Matches version against regexpClassical without the regexp engine
+//
+// Returns the same submatches than regexpClassical.FindStringSubmatch, this
+// is the hot path of Normalize.
+func scanClassical(version string) []string {
+	i, end := 0, len(version)
+	if i < end && (version[i] == 'v' || version[i] == 'V') {
+		i++
+	}
+
+	result := make([]string, 8)
+
+	// major, at most three digits
+	digits := scanDigits(version, i)
+	if digits == i || digits-i > 3 {
+		return nil
+	}
+	result[1] = version[i:digits]
+	i = digits
+
+	// minor, patch and build, a dot followed by digits
+	for group := 2; group <= 4; group++ {
+		if i >= end || version[i] != '.' {
+			break
+		}
+
+		digits = scanDigits(version, i+1)
+		if digits == i+1 {
+			break
+		}
+
+		result[group] = version[i:digits]
+		i = digits
+	}
+
+	if i < end && (version[i] == '.' || version[i] == '_' || version[i] == '-') {
+		i++
+	}
+
+	for _, word := range stabilityWords {
+		if len(version)-i >= len(word) && strings.EqualFold(version[i:i+len(word)], word) {
+			result[5] = version[i : i+len(word)]
+			i += len(word)
+
+			number := i
+			if number < end && (version[number] == '.' || version[number] == '-') {
+				number++
+			}
+
+			if digits = scanDigits(version, number); digits > number {
+				result[6] = version[number:digits]
+				i = digits
+			}
+
+			break
+		}
+	}
+
+	dev := i
+	if dev < end && (version[dev] == '.' || version[dev] == '-') {
+		dev++
+	}
+
+	if len(version)-dev == 3 && strings.EqualFold(version[dev:], "dev") {
+		result[7] = version[i:]
+		i = end
+	}
+
+	if i != end {
+		return nil
+	}
+
+	result[0] = version
+
+	return result
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+// date-based versions start with at least four digits
+func hasDatePrefix(version string) bool {
+	start := 0
+	if start < len(version) && (version[0] == 'v' || version[0] == 'V') {
+		start++
+	}
+
+	return scanDigits(version, start)-start >= 4
+}
+
+func scanDigits(subject string, from int) int {
+	for from < len(subject) && subject[from] >= '0' && subject[from] <= '9' {
+		from++
+	}
+
+	return from
+}
@@ -1,6 +1,8 @@
 package version
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -34,6 +36,9 @@ var versions = map[string]string{
 	"DEV-FOOBAR":          "dev-FOOBAR",
 	"dev-feature/foo":     "dev-feature/foo",
 	"dev-master as 1.0.0": "9999999-dev",
+	"1.0.0+20130313":      "1.0.0.0+20130313",
+	"v1.0-b2+exp.sha.5f8": "1.0.0.0-beta2+exp.sha.5f8",
+	"2010-01-02+build.1":  "2010-01-02+build.1",
 }
 
 func TestNormalize(t *testing.T) {
@@ -66,3 +71,65 @@ func TestNormalizeBranch(t *testing.T) {
 		}
 	}
 }
+
+var strictErrors = map[string]error{
+	"":               ErrEmptyVersion,
+	"banana":         ErrInvalidVersion,
+	"1.2.3.4.5.6":    ErrTooManySegments,
+	"1.0-foo":        ErrUnknownModifier,
+	"1.0.0-beta!":    ErrUnknownModifier,
+	"2010-1-2":       ErrBadDate,
+	"2010-01-02-foo": ErrBadDate,
+	"1.0+":           ErrUnknownModifier,
+	"1.0+build_1":    ErrUnknownModifier,
+}
+
+func TestNormalizeStrict(t *testing.T) {
+	for in, out := range versions {
+		if x, err := NormalizeStrict(in); x != out || err != nil {
+			t.Errorf("FAIL: NormalizeStrict(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for in, out := range strictErrors {
+		x, err := NormalizeStrict(in)
+		if !errors.Is(err, out) {
+			t.Errorf("FAIL: NormalizeStrict(%v) = %v, %v: want error %v", in, x, err, out)
+		}
+
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("FAIL: NormalizeStrict(%v) error is %T: want *ParseError", in, err)
+		}
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	versions := benchmarkVersions(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, version := range versions {
+			Normalize(version)
+		}
+	}
+}
+
+func TestScanClassicalMatchesRegexp(t *testing.T) {
+	inputs := []string{
+		"", "v", "1", "V1", "1234", "123", "1.", "1.0.", "1-", "1.0.0.0.0", "1.a",
+		"1.0-dev", "1.0_dev", "1.0.-dev", "1.0--dev", "1.0dev", "1.0DEV", "1.0-deva",
+		"1.0-beta-dev", "1.0-b-dev", "1.0-bdev", "1.0-betadev", "1.0-b.5", "1.0-rc1.5",
+		"1.0pl3", "1.0p1", "1.0-patch", "1.0-patchdev", "1.0-ab", "1.0-Alpha2", "1.0_rc_1",
+		"1.0.0RC1dev", "1.2.3.4-5", "1-stable", "1.0-STABLE", "1.0-stable2-dev",
+	}
+	for in := range versions {
+		inputs = append(inputs, in)
+	}
+	inputs = append(inputs, benchmarkVersions(500)...)
+
+	for _, in := range inputs {
+		if x, out := scanClassical(in), regexpClassical.FindStringSubmatch(in); !reflect.DeepEqual(x, out) {
+			t.Errorf("FAIL: scanClassical(%q) = %q: want %q", in, x, out)
+		}
+	}
+}
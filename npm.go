@@ -0,0 +1,418 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NPM is the scheme of npm packages, registered as "npm"
+//
+// Versions are Semantic Versioning 2.0.0 versions, a leading v or = being
+// ignored like node-semver does, and constraints are npm ranges.
+var NPM Scheme = npmScheme{}
+
+func init() {
+	RegisterScheme("npm", NPM)
+}
+
+// NPMOptions changes how npm ranges match versions
+type NPMOptions struct {
+	// Match pre-releases like any other version, instead of only when a
+	// comparator of the range names a pre-release of the same version
+	IncludePrerelease bool
+}
+
+// Parses an npm range, like ^1.2.x or >=1.2.7 <1.3.0 || 2.x
+//
+// Ranges use the syntax of node-semver: comparators (<, <=, >, >=, =),
+// hyphenated ranges, X-ranges, tilde and caret ranges, joined by spaces and
+// alternated with ||. Like npm, a pre-release only matches when a comparator
+// of the same set names a pre-release of the same major, minor and patch,
+// use ParseNPMRangeWithOptions to change that.
+//
+// The range is returned as a ConstraintGroup holding one alternative per ||
+// set, each of them desugared to plain comparators: ^1.2.3 becomes
+// >=1.2.3 <2.0.0-0. Malformed ranges are reported as a *ConstraintError.
+//
+// Usage
+//     c, _ := version.ParseNPMRange(">=1.2.7 <1.3.0 || 2.x")
+//     c.Match("2.4.1")
+//     Returns: true
+//
+//     c.Match("1.2.8-beta.1")
+//     Returns: false
+func ParseNPMRange(rng string) (*ConstraintGroup, error) {
+	return ParseNPMRangeWithOptions(rng, NPMOptions{})
+}
+
+// Parses an npm range like ParseNPMRange, matching versions with options
+func ParseNPMRangeWithOptions(rng string, options NPMOptions) (*ConstraintGroup, error) {
+	if i := strings.IndexByte(rng, ','); i >= 0 {
+		return nil, &ConstraintError{rng, ",", i, ErrInvalidConstraint}
+	}
+
+	admit, admitted := admitNPM, admittedNPM
+	if options.IncludePrerelease {
+		// pre-releases are matched like releases, the range needs no split
+		admit, admitted = admitSemVer, nil
+	}
+
+	group := &ConstraintGroup{scheme: NPM}
+
+	start := 0
+	sets := strings.Split(rng, "||")
+	for _, set := range sets {
+		alternative := &ConstraintGroup{scheme: NPM, admit: admit, admitted: admitted}
+		for _, clause := range splitClauses(set) {
+			constraints, err := parseNPMClause(clause.text, options.IncludePrerelease)
+			if err != nil {
+				return nil, &ConstraintError{rng, clause.text, start + clause.offset, err}
+			}
+
+			alternative.AddConstraint(constraints...)
+		}
+
+		if len(sets) == 1 {
+			return alternative, nil
+		}
+
+		group.AddAlternative(alternative)
+		start += len(set) + 2
+	}
+
+	return group, nil
+}
+
+// version of a range, any of its numbers may be a wildcard
+type npmPartial struct {
+	numbers    [3]int
+	wildcards  int // number of trailing missing or wildcard numbers
+	prerelease string
+}
+
+func (self npmPartial) String() string {
+	version := strconv.Itoa(self.numbers[0]) + "." + strconv.Itoa(self.numbers[1]) + "." + strconv.Itoa(self.numbers[2])
+	if self.prerelease != "" {
+		version = version + "-" + self.prerelease
+	}
+
+	return version
+}
+
+// increments the number at position, 0 being the major, and resets the
+// following ones
+func (self npmPartial) bump(position int) npmPartial {
+	bumped := npmPartial{}
+	copy(bumped.numbers[:], self.numbers[:position])
+	bumped.numbers[position] = self.numbers[position] + 1
+
+	return bumped
+}
+
+// the lowest version of a partial, 1.2.x being 1.2.0
+func (self npmPartial) floor() npmPartial {
+	floor := self
+	for i := 3 - self.wildcards; i < 3; i++ {
+		floor.numbers[i] = 0
+	}
+
+	return floor
+}
+
+var npmOperators = []string{"<=", ">=", "~>", "<", ">", "=", "~", "^"}
+
+// desugars a clause of a range into plain comparators
+func parseNPMClause(clause string, includePrerelease bool) ([]*Constraint, error) {
+	// the lowest bound of a wildcard, 0.0.0-0 when pre-releases are included
+	z := ""
+	if includePrerelease {
+		z = "-0"
+	}
+
+	if words := strings.Fields(clause); len(words) == 3 && words[1] == "-" {
+		low, err := parseNPMPartial(words[0])
+		if err != nil {
+			return nil, err
+		}
+
+		high, err := parseNPMPartial(words[2])
+		if err != nil {
+			return nil, err
+		}
+
+		constraints := make([]*Constraint, 0, 2)
+		switch {
+		case low.wildcards == 3:
+		case low.prerelease != "":
+			constraints = append(constraints, &Constraint{">=", low.String()})
+		default:
+			constraints = append(constraints, &Constraint{">=", low.floor().String() + z})
+		}
+
+		switch {
+		case high.wildcards == 3:
+		case high.wildcards > 0:
+			constraints = append(constraints, &Constraint{"<", high.bump(2-high.wildcards).String() + "-0"})
+		case high.prerelease == "" && includePrerelease:
+			constraints = append(constraints, &Constraint{"<", high.bump(2).String() + "-0"})
+		default:
+			constraints = append(constraints, &Constraint{"<=", high.String()})
+		}
+
+		return constraints, nil
+	}
+
+	operator := ""
+	for _, candidate := range npmOperators {
+		if strings.HasPrefix(clause, candidate) {
+			operator = candidate
+			break
+		}
+	}
+
+	partial, err := parseNPMPartial(strings.TrimSpace(clause[len(operator):]))
+	if err != nil {
+		return nil, err
+	}
+
+	low := partial.floor()
+
+	switch operator {
+	case "~", "~>":
+		switch partial.wildcards {
+		case 3:
+			return []*Constraint{}, nil
+		case 2:
+			return []*Constraint{{">=", low.String()}, {"<", partial.bump(0).String() + "-0"}}, nil
+		case 1:
+			return []*Constraint{{">=", low.String()}, {"<", partial.bump(1).String() + "-0"}}, nil
+		}
+
+		return []*Constraint{{">=", partial.String()}, {"<", partial.bump(1).String() + "-0"}}, nil
+	case "^":
+		if partial.wildcards == 3 {
+			return []*Constraint{}, nil
+		}
+
+		// like node-semver, a full version only gets the lowest pre-release
+		// below a 0 major
+		lowest := low.String() + z
+		if partial.prerelease != "" || (partial.wildcards == 0 && partial.numbers[0] != 0) {
+			lowest = partial.String()
+		}
+
+		// the left-most non-zero number may not change
+		position := 0
+		switch {
+		case partial.numbers[0] != 0 || partial.wildcards == 2:
+		case partial.numbers[1] != 0 || partial.wildcards == 1:
+			position = 1
+		default:
+			position = 2
+		}
+
+		return []*Constraint{{">=", lowest}, {"<", partial.bump(position).String() + "-0"}}, nil
+	}
+
+	if partial.wildcards == 3 {
+		if operator == "<" || operator == ">" {
+			return []*Constraint{{"<", "0.0.0-0"}}, nil
+		}
+
+		return []*Constraint{}, nil
+	}
+
+	if partial.wildcards > 0 {
+		position := 2 - partial.wildcards
+
+		switch operator {
+		case ">":
+			return []*Constraint{{">=", partial.bump(position).String() + z}}, nil
+		case "<=":
+			return []*Constraint{{"<", partial.bump(position).String() + "-0"}}, nil
+		case "<":
+			return []*Constraint{{"<", low.String() + "-0"}}, nil
+		case ">=":
+			return []*Constraint{{">=", low.String() + z}}, nil
+		}
+
+		return []*Constraint{{">=", low.String() + z}, {"<", partial.bump(position).String() + "-0"}}, nil
+	}
+
+	if operator == "" {
+		operator = "="
+	}
+
+	return []*Constraint{{operator, partial.String()}}, nil
+}
+
+// parses a version of a range, like 1.2, 1.x or v1.2.3-beta.1
+func parseNPMPartial(partial string) (npmPartial, error) {
+	version := strings.TrimLeft(partial, "v=")
+	if version == "" {
+		return npmPartial{}, &ParseError{partial, ErrInvalidSemVer, ""}
+	}
+
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		if _, err := splitIdentifiers(partial, version[i+1:], false); err != nil {
+			return npmPartial{}, err
+		}
+		version = version[:i]
+	}
+
+	result := npmPartial{}
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		if _, err := splitIdentifiers(partial, version[i+1:], true); err != nil {
+			return npmPartial{}, err
+		}
+		result.prerelease = version[i+1:]
+		version = version[:i]
+	}
+
+	segments := strings.Split(version, ".")
+	if len(segments) > 3 {
+		return npmPartial{}, &ParseError{partial, ErrInvalidSemVer, "more than 3 numbers"}
+	}
+
+	result.wildcards = 3 - len(segments)
+	for i, segment := range segments {
+		if segment == "x" || segment == "X" || segment == "*" {
+			if result.wildcards < 3-i {
+				result.wildcards = 3 - i
+			}
+			continue
+		}
+
+		if !isNumericIdentifier(segment) {
+			return npmPartial{}, &ParseError{partial, ErrInvalidSemVer, "invalid number " + strconv.Quote(segment)}
+		}
+
+		number, err := strconv.Atoi(segment)
+		if err != nil {
+			return npmPartial{}, &ParseError{partial, ErrInvalidSemVer, "number out of range " + strconv.Quote(segment)}
+		}
+		result.numbers[i] = number
+	}
+
+	if result.prerelease != "" && result.wildcards > 0 {
+		return npmPartial{}, &ParseError{partial, ErrInvalidSemVer, "pre-release of a partial version"}
+	}
+
+	return result, nil
+}
+
+// parses a version like node-semver, ignoring a leading v or =
+func parseNPMVersion(version string) (SemVer, error) {
+	v, err := ParseSemVer(strings.TrimLeft(strings.TrimSpace(version), "v="))
+	if err != nil {
+		return SemVer{}, err
+	}
+	v.original = version
+
+	return v, nil
+}
+
+// a pre-release only matches when a comparator names a pre-release of the
+// same major, minor and patch
+func admitNPM(version string, constraints []*Constraint) bool {
+	return admitPrerelease(parseNPMVersion, version, constraints)
+}
+
+func admitPrerelease(parse func(string) (SemVer, error), version string, constraints []*Constraint) bool {
+	v, err := parse(version)
+	if err != nil {
+		return false
+	}
+
+	if len(v.prerelease) == 0 {
+		return true
+	}
+
+	for _, constraint := range constraints {
+		bound, err := parse(constraint.version)
+		if err == nil && len(bound.prerelease) > 0 &&
+			bound.major == v.major && bound.minor == v.minor && bound.patch == v.patch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func admittedNPM(constraints []*Constraint) *Range {
+	return admittedPrereleases(NPM, parseNPMVersion, constraints)
+}
+
+// the range admitPrerelease lets through: every release, and the
+// pre-releases of the versions comparators name a pre-release of
+func admittedPrereleases(scheme Scheme, parse func(string) (SemVer, error), constraints []*Constraint) *Range {
+	windows := make([]interval, 0)
+	for _, constraint := range constraints {
+		bound, err := parse(constraint.version)
+		if err == nil && len(bound.prerelease) > 0 {
+			windows = append(windows, prereleaseWindow(bound.release()))
+		}
+	}
+
+	return newSplitRange(scheme, []interval{{noBound, noBound}}, windows)
+}
+
+// the pre-releases of release, from release-0 up to release
+func prereleaseWindow(release string) interval {
+	return interval{bound{version: release + "-0", inclusive: true}, bound{version: release}}
+}
+
+func admitSemVer(version string, constraints []*Constraint) bool {
+	_, err := parseNPMVersion(version)
+
+	return err == nil
+}
+
+type npmScheme struct{}
+
+func (npmScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := parseNPMVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (npmScheme) Compare(version1, version2 string) int {
+	v1, err1 := parseNPMVersion(version1)
+	v2, err2 := parseNPMVersion(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+func (npmScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(SemVer).Compare(v2.(SemVer))
+}
+
+func (npmScheme) releaseOf(version string) (string, bool) {
+	v, err := parseNPMVersion(version)
+
+	return v.release(), err == nil && len(v.prerelease) > 0
+}
+
+func (npmScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseNPMRange(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// drops the leading v or = and the build metadata, like semver.clean
+func (npmScheme) Format(version string) (string, error) {
+	v, err := parseNPMVersion(version)
+	if err != nil {
+		return "", err
+	}
+	v.build = nil
+
+	return v.String(), nil
+}
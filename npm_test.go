@@ -0,0 +1,253 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// from the range-include and range-exclude fixtures of node-semver
+var npmRanges = map[string]bool{
+	"1.0.0 - 2.0.0|1.2.3":                         true,
+	"1.0.0 - 2.0.0|2.2.3":                         false,
+	"^1.2.3+build|1.2.3":                          true,
+	"^1.2.3+build|1.3.0":                          true,
+	"1.2.3-pre+asdf - 2.4.3-pre+asdf|1.2.3":       true,
+	"1.2.3-pre+asdf - 2.4.3-pre+asdf|2.4.3-alpha": true,
+	"1.2.3-pre+asdf - 2.4.3-pre+asdf|2.4.3":       false,
+	"1.2.3 - 2.3|2.3.9":                           true,
+	"1.2.3 - 2.3|2.4.0":                           false,
+	"1.2 - 2.3.4|1.2.0":                           true,
+	"1.2 - 2.3.4|1.1.9":                           false,
+	"1.0.0|1.0.0":                                 true,
+	"1.0.0|1.0.1":                                 false,
+	">=*|0.2.4":                                   true,
+	"|1.0.0":                                      true,
+	"*|1.2.3":                                     true,
+	"*|v1.2.3":                                    true,
+	"*|1.2.3-foo":                                 false,
+	">=1.0.0|1.0.0":                               true,
+	">=1.0.0|0.0.1":                               false,
+	">1.0.0|1.0.1":                                true,
+	">1.0.0|1.0.0":                                false,
+	"<=2.0.0|2.0.0":                               true,
+	"<=2.0.0|2.0.1":                               false,
+	"<2.0.0|1.9999.9999":                          true,
+	"<2.0.0|2.0.0":                                false,
+	">= 1.0.0|1.0.0":                              true,
+	">=0.1.97|v0.1.97":                            true,
+	"0.1.20 || 1.2.4|1.2.4":                       true,
+	"0.1.20 || 1.2.4|1.2.3":                       false,
+	">=0.2.3 || <0.0.1|0.0.0":                     true,
+	">=0.2.3 || <0.0.1|0.2.2":                     false,
+	"|||1.3.4":                                    true,
+	"2.x.x|2.1.3":                                 true,
+	"2.x.x|3.1.3":                                 false,
+	"1.2.x|1.2.3":                                 true,
+	"1.2.x|1.3.3":                                 false,
+	"1.2.x || 2.x|2.1.3":                          true,
+	"1.2.x || 2.x|1.1.3":                          false,
+	"x|1.2.3":                                     true,
+	"2.*.*|2.1.3":                                 true,
+	"1.2.*|1.2.3":                                 true,
+	"2|2.1.2":                                     true,
+	"2.3|2.3.1":                                   true,
+	"2.3|2.4.1":                                   false,
+	"~0.0.1|0.0.1":                                true,
+	"~0.0.1|0.0.2":                                true,
+	"~0.0.1|0.1.0":                                false,
+	"~x|0.0.9":                                    true,
+	"~2|2.0.9":                                    true,
+	"~2|3.0.0":                                    false,
+	"~2.4|2.4.5":                                  true,
+	"~2.4|2.5.0":                                  false,
+	"~>3.2.1|3.2.2":                               true,
+	"~1|1.2.3":                                    true,
+	"~>1|1.2.3":                                   true,
+	"~1.0|1.0.2":                                  true,
+	"~1.0|1.1.0":                                  false,
+	">=1|1.0.0":                                   true,
+	">1|1.9.9":                                    false,
+	">1|2.0.0":                                    true,
+	"<1|0.9.9":                                    true,
+	"<1|1.0.0-beta":                               false,
+	"<=1|1.9.9":                                   true,
+	"<=1|2.0.0-0":                                 false,
+	"<1.2|1.1.1":                                  true,
+	"<1.2|1.2.0":                                  false,
+	">1.2|1.3.0":                                  true,
+	">1.2|1.2.9":                                  false,
+	"~v0.5.4-pre|0.5.5":                           true,
+	"~v0.5.4-pre|0.5.4":                           true,
+	"~v0.5.4-pre|0.5.4-pre":                       true,
+	"~v0.5.4-pre|0.6.0":                           false,
+	"=0.7.x|0.7.2":                                true,
+	"<=0.7.x|0.7.2":                               true,
+	">=0.7.x|0.7.2":                               true,
+	"<=0.7.x|0.6.2":                               true,
+	"<=0.7.x|0.8.0":                               false,
+	"~1.2.1 >=1.2.3|1.2.3":                        true,
+	"~1.2.1 =1.2.3|1.2.3":                         true,
+	"~1.2.1 1.2.3|1.2.3":                          true,
+	"~1.2.1 >=1.2.3 1.2.3|1.2.3":                  true,
+	">=1.2.1 1.2.3|1.2.3":                         true,
+	"1.2.3 >=1.2.1|1.2.3":                         true,
+	">=1.2.3 >=1.2.1|1.2.3":                       true,
+	">=1.2.1 >=1.2.3|1.2.3":                       true,
+	">=1.2|1.2.8":                                 true,
+	"^1.2.3|1.8.1":                                true,
+	"^1.2.3|2.0.0":                                false,
+	"^1.2.3|1.2.2":                                false,
+	"^0.1.2|0.1.2":                                true,
+	"^0.1.2|0.2.0":                                false,
+	"^0.1|0.1.2":                                  true,
+	"^0.0.1|0.0.1":                                true,
+	"^0.0.1|0.0.2":                                false,
+	"^0.0|0.0.9":                                  true,
+	"^0.0|0.1.0":                                  false,
+	"^0|0.9.9":                                    true,
+	"^0|1.0.0":                                    false,
+	"^1.2|1.4.2":                                  true,
+	"^1.2 ^1|1.4.2":                               true,
+	"^1.2.3-alpha|1.2.3-pre":                      true,
+	"^1.2.3-alpha|1.2.3-0":                        false,
+	"^1.2.0-alpha|1.2.0-pre":                      true,
+	"^0.0.1-alpha|0.0.1-beta":                     true,
+	"^0.0.1-alpha|0.0.1":                          true,
+	"^0.1.1-alpha|0.1.1-beta":                     true,
+	"^x|1.2.3":                                    true,
+	"x - 1.0.0|0.9.7":                             true,
+	"x - 1.x|0.9.7":                               true,
+	"1.0.0 - x|1.9.7":                             true,
+	"1.x - x|1.9.7":                               true,
+	"<=7.x|7.9.9":                                 true,
+	"^1.2.3|1.2.4-beta":                           false,
+	"^1.2.3-beta.2|1.2.4-beta.3":                  false,
+	"^1.2.3-beta.2|1.2.3-beta.4":                  true,
+	">=1.2.3-beta.2 <1.2.4|1.2.3-beta.4":          true,
+	"<1.2.3 || >1.2.3-beta.1|1.2.3-beta.2":        true,
+	">1.0.0 <1.0.0|1.0.0":                         false,
+	"<*|1.0.0":                                    false,
+	">*|1.0.0":                                    false,
+	"*|banana":                                    false,
+	">=1.0.0|banana":                              false,
+	"<2.0.0|banana":                               false,
+}
+
+func TestParseNPMRange(t *testing.T) {
+	for in, out := range npmRanges {
+		i := strings.LastIndex(in, "|")
+		rng, v := in[:i], in[i+1:]
+
+		c, err := ParseNPMRange(rng)
+		if err != nil {
+			t.Errorf("FAIL: ParseNPMRange(%v) error = %v", rng, err)
+			continue
+		}
+
+		if x := c.Match(v); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var npmRangesIncludePrerelease = map[string]bool{
+	"*|1.0.0-rc1":                   true,
+	"^2 <2.2 || > 2.3|2.2.1-beta.0": false,
+	"^2 <2.2 || > 2.3|2.3.1-beta.0": false,
+	"^2 <2.2 || > 2.3|2.4.1-beta.0": true,
+	"^1.0.0|1.0.1-rc1":              true,
+	"^1.0.0|2.0.0-rc1":              false,
+	"^1.0.0-0|1.0.1-rc1":            true,
+	"^1.x|1.0.0-rc1":                true,
+	"1.0.0 - 2.0.0|2.0.0-rc1":       true,
+	"1.0.0 - 2.0.0|2.0.1-rc1":       false,
+	"~1.2.3|1.2.4-0":                true,
+	"<=1.x|1.9.9-rc":                true,
+	">1.x|2.0.0-rc":                 true,
+	"~1.2|1.2.0-beta":               false,
+	"~1.2|1.2.1-beta":               true,
+	"^1.2.3|1.2.3-beta":             false,
+	"^0.2.3|0.2.3-beta":             true,
+}
+
+func TestParseNPMRangeIncludePrerelease(t *testing.T) {
+	for in, out := range npmRangesIncludePrerelease {
+		i := strings.LastIndex(in, "|")
+		rng, v := in[:i], in[i+1:]
+
+		c, err := ParseNPMRangeWithOptions(rng, NPMOptions{IncludePrerelease: true})
+		if err != nil {
+			t.Errorf("FAIL: ParseNPMRangeWithOptions(%v) error = %v", rng, err)
+			continue
+		}
+
+		if x := c.Match(v); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var npmDesugared = map[string]string{
+	"^1.2.3":      ">=1.2.3 <2.0.0-0",
+	"^0.0.3":      ">=0.0.3 <0.0.4-0",
+	"~1.2":        ">=1.2.0 <1.3.0-0",
+	"1.x":         ">=1.0.0 <2.0.0-0",
+	">1.2":        ">=1.3.0",
+	"<=1.2":       "<1.3.0-0",
+	"1.2.3 - 2.3": ">=1.2.3 <2.4.0-0",
+	"1.2.3":       "=1.2.3",
+	">= v1.2.3":   ">=1.2.3",
+	"*":           "",
+}
+
+func TestParseNPMRangeDesugared(t *testing.T) {
+	for in, out := range npmDesugared {
+		c, _ := ParseNPMRange(in)
+
+		constraints := make([]string, 0)
+		for _, constraint := range c.GetConstraints() {
+			constraints = append(constraints, constraint.GetOperator()+constraint.GetVersion())
+		}
+
+		if x := strings.Join(constraints, " "); x != out {
+			t.Errorf("FAIL: ParseNPMRange(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var npmRangeErrors = map[string]error{
+	">=1.0.0, <2.0.0": ErrInvalidConstraint,
+	"blerg":           ErrInvalidSemVer,
+	"^1.2.3.4":        ErrInvalidSemVer,
+	"1.2.x-beta":      ErrInvalidSemVer,
+	">=01.2.3":        ErrInvalidSemVer,
+	"1.2.3 || ~>x.y":  ErrInvalidSemVer,
+}
+
+func TestParseNPMRangeErrors(t *testing.T) {
+	for in, out := range npmRangeErrors {
+		c, err := NPM.ParseConstraint(in)
+		if c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: NPM.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+
+	_, err := ParseNPMRange("1.2.3 || ~>x.y")
+	if e, ok := err.(*ConstraintError); !ok || e.Clause != "~>x.y" || e.Offset != 9 {
+		t.Errorf("FAIL: ParseNPMRange(1.2.3 || ~>x.y) error = %#v: want clause ~>x.y at offset 9", err)
+	}
+}
+
+func TestNPMScheme(t *testing.T) {
+	for in, out := range map[string]int{"v1.2.3|=1.2.3": 0, "1.2.3-beta|1.2.3": -1, "1.2.3+build|1.2.3": 0, "banana|0.0.0": -1} {
+		v := strings.Split(in, "|")
+		if x := NPM.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: NPM.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	if x, err := NPM.Format(" =v1.2.3-beta+build "); x != "1.2.3-beta" || err != nil {
+		t.Errorf("FAIL: NPM.Format(=v1.2.3-beta+build) = %v, %v: want %v", x, err, "1.2.3-beta")
+	}
+}
@@ -0,0 +1,271 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PacmanVersion is a version of an Arch Linux package, epoch:pkgver-pkgrel
+//
+// Versions are ordered like vercmp does, the release only being compared when
+// both versions have one.
+type PacmanVersion struct {
+	original string
+	epoch    string
+	version  string
+	release  string
+}
+
+// Pacman is the scheme of Arch Linux packages, registered as "pacman"
+//
+// Constraints are comma separated comparisons using the operators <, <=, =,
+// >= and >. A comparison without release, like >= 1.0, matches every release.
+var Pacman Scheme = pacmanScheme{}
+
+var pacmanOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+}
+
+func init() {
+	RegisterScheme("pacman", Pacman)
+}
+
+// Parses an Arch Linux package version
+//
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParsePacman("1:2.3.4-2")
+//     v.Epoch()
+//     Returns: "1"
+func ParsePacman(version string) (PacmanVersion, error) {
+	rest := strings.TrimSpace(version)
+	if rest == "" {
+		return PacmanVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if strings.ContainsAny(rest, " \t/") {
+		return PacmanVersion{}, &ParseError{version, ErrInvalidVersion, "invalid character"}
+	}
+
+	v := PacmanVersion{original: version, epoch: "0"}
+
+	// the epoch is made of the digits before a colon
+	digits := scanDigits(rest, 0)
+	if digits < len(rest) && rest[digits] == ':' {
+		if digits > 0 {
+			v.epoch = rest[:digits]
+		}
+		rest = rest[digits+1:]
+	}
+
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.release = rest[i+1:]
+		if v.release == "" {
+			return PacmanVersion{}, &ParseError{version, ErrInvalidVersion, "empty release"}
+		}
+		rest = rest[:i]
+	}
+
+	v.version = rest
+	if v.version == "" {
+		return PacmanVersion{}, &ParseError{version, ErrInvalidVersion, "empty version"}
+	}
+
+	return v, nil
+}
+
+// Compares two Arch Linux package versions, like vercmp
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.ComparePacman("1.0rc1", "1.0")
+//     Returns: -1
+//
+//     version.ComparePacman("1:1.0-1", "2.0-1")
+//     Returns: 1
+func ComparePacman(version1, version2 string) int {
+	v1, err1 := ParsePacman(version1)
+	v2, err2 := ParsePacman(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the epoch, "0" when not given
+func (self PacmanVersion) Epoch() string {
+	return self.epoch
+}
+
+// Return the version, without epoch and release
+func (self PacmanVersion) Version() string {
+	return self.version
+}
+
+// Return the release, empty when not given
+func (self PacmanVersion) Release() string {
+	return self.release
+}
+
+// Return the string given to ParsePacman
+func (self PacmanVersion) Original() string {
+	return self.original
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self PacmanVersion) Compare(other PacmanVersion) int {
+	if cmp := alpmvercmp(self.epoch, other.epoch); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := alpmvercmp(self.version, other.version); cmp != 0 || self.release == "" || other.release == "" {
+		return cmp
+	}
+
+	return alpmvercmp(self.release, other.release)
+}
+
+// Return true if both versions are equal
+func (self PacmanVersion) Equal(other PacmanVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self PacmanVersion) Less(other PacmanVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version, the epoch being left out when 0
+func (self PacmanVersion) String() string {
+	version := self.version
+	if self.epoch != "0" {
+		version = self.epoch + ":" + version
+	}
+
+	if self.release != "" {
+		version = version + "-" + self.release
+	}
+
+	return version
+}
+
+// Compares versions or releases like the rpmvercmp of libalpm
+//
+// Strings are split in runs of digits and runs of letters like rpmvercmp,
+// without its ~ and ^ rules, but the longest separator wins and a trailing
+// run of letters is older than the end of the other version: 1.0rc1 < 1.0.
+func alpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start1, start2 := i, j
+		for i < len(a) && !isAlnum(a[i]) {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) {
+			j++
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		if cmp := compareInt(i-start1, j-start2); cmp != 0 {
+			return cmp
+		}
+
+		numeric := isDigit(a[i])
+		var end1, end2 int
+		if numeric {
+			end1, end2 = scanDigits(a, i), scanDigits(b, j)
+		} else {
+			end1, end2 = scanLetters(a, i), scanLetters(b, j)
+		}
+
+		// numbers are newer than letters
+		if end2 == j {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		segment1, segment2 := a[i:end1], b[j:end2]
+		if numeric {
+			segment1, segment2 = strings.TrimLeft(segment1, "0"), strings.TrimLeft(segment2, "0")
+			if cmp := compareInt(len(segment1), len(segment2)); cmp != 0 {
+				return cmp
+			}
+		}
+
+		if cmp := strings.Compare(segment1, segment2); cmp != 0 {
+			return cmp
+		}
+
+		i, j = end1, end2
+	}
+
+	rest1, rest2 := a[i:], b[j:]
+	switch {
+	case rest1 == "" && rest2 == "":
+		return 0
+	case rest1 == "" && !isLetter(rest2) || isLetter(rest1):
+		return -1
+	}
+
+	return 1
+}
+
+// tells if a string starts with a letter
+func isLetter(subject string) bool {
+	return subject != "" && isAlnum(subject[0]) && !isDigit(subject[0])
+}
+
+type pacmanScheme struct{}
+
+func (pacmanScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParsePacman(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (pacmanScheme) Compare(version1, version2 string) int {
+	return ComparePacman(version1, version2)
+}
+
+func (pacmanScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(PacmanVersion).Compare(v2.(PacmanVersion))
+}
+
+func (pacmanScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := parseRelations(Pacman, constraint, pacmanOperators)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (pacmanScheme) Format(version string) (string, error) {
+	v, err := ParsePacman(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
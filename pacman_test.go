@@ -0,0 +1,111 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var pacmanValues = map[string][3]string{
+	"1.0":       {"0", "1.0", ""},
+	"1.0-1":     {"0", "1.0", "1"},
+	"1:2.3.4-2": {"1", "2.3.4", "2"},
+	":1.0-1":    {"0", "1.0", "1"},
+	"1.0rc1":    {"0", "1.0rc1", ""},
+	"a:1.0":     {"0", "a:1.0", ""},
+}
+
+func TestParsePacman(t *testing.T) {
+	for in, out := range pacmanValues {
+		v, err := ParsePacman(in)
+		if x := [3]string{v.Epoch(), v.Version(), v.Release()}; err != nil || x != out || v.Original() != in {
+			t.Errorf("FAIL: ParsePacman(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"1.0-", "-1", "1:", "1:-1", "1.0 1", "1/0"} {
+		if v, err := ParsePacman(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParsePacman(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// as given by vercmp
+var comparePacmanValues = map[string]int{
+	"1.5.0|1.5.0":     0,
+	"1.5.1|1.5.0":     1,
+	"1.5.1|1.5":       1,
+	"1.5.0-1|1.5.0-1": 0,
+	"1.5.0-1|1.5.0-2": -1,
+	"1.5.0-1|1.5.1-1": -1,
+	"1.5.0-2|1.5.1-1": -1,
+	"1.5-1|1.5":       0,
+	"1.5|1.5-1":       0,
+	"1.1-1|1.1":       0,
+	"1.0-1|1.0-1.1":   -1,
+	"1.1-1|1.1-1.1":   -1,
+	"1.0|1.0a":        1,
+	"1.0a|1.0alpha":   -1,
+	"1.0rc1|1.0":      -1,
+	"1.0alpha|1.0b":   -1,
+	"1.0b|1.0beta":    -1,
+	"1.0beta|1.0rc":   -1,
+	"1.0rc|1.0":       -1,
+	"1.5.a|1.5":       1,
+	"1.5.b|1.5.a":     1,
+	"1.5.1|1.5.b":     1,
+	"1.5.b-1|1.5.b":   0,
+	"1.5-1|1.5.b":     -1,
+	"1.5.pre1|1.5":    1,
+	"1.5|1.5.1":       -1,
+	"1.5..1|1.5.1":    1,
+	"1.5_1|1.5.1":     0,
+	"1.5.|1.5":        1,
+	"0:1.0|1.0":       0,
+	"0:1.0|1:1.0":     -1,
+	"1:1.0|2.0":       1,
+	"2:1.0|1:1.0":     1,
+	"1:1.0-1|1:1.0-2": -1,
+	"1.0+git1|1.0":    1,
+	"20230101|2.0":    1,
+	"x y|1.0":         -1,
+}
+
+func TestComparePacman(t *testing.T) {
+	for in, out := range comparePacmanValues {
+		v := strings.Split(in, "|")
+		if x := ComparePacman(v[0], v[1]); x != out {
+			t.Errorf("FAIL: ComparePacman(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := ComparePacman(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: ComparePacman(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := Pacman.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: Pacman.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestSortWithPacman(t *testing.T) {
+	versions := []string{"1:0.9-1", "1.0-2", "1.0rc1-1", "1.0-1", "0.9"}
+	SortWith(Pacman, versions)
+
+	if x := strings.Join(versions, " "); x != "0.9 1.0rc1-1 1.0-1 1.0-2 1:0.9-1" {
+		t.Errorf("FAIL: SortWith(Pacman) = %v", x)
+	}
+}
+
+func TestParsePacmanConstraint(t *testing.T) {
+	c, err := Pacman.ParseConstraint(">= 1.0, < 2.0-1")
+	if err != nil {
+		t.Fatalf("FAIL: Pacman.ParseConstraint error = %v", err)
+	}
+
+	for in, out := range map[string]bool{"1.0-1": true, "1.0rc1-1": false, "2.0-1": false, "2.0": false, "1:0.1": false, "not a version!": false, "": false} {
+		if x := c.Match(in); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
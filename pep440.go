@@ -0,0 +1,582 @@
+package version
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var regexpPEP440 = regexp.MustCompile(`(?i)^v?` +
+	`(?:(\d+)!)?` +
+	`(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(alpha|a|beta|b|preview|pre|c|rc)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+var pep440PreReleases = map[string]string{
+	"a": "a", "alpha": "a",
+	"b": "b", "beta": "b",
+	"c": "rc", "rc": "rc", "pre": "rc", "preview": "rc",
+}
+
+// ranks of the pre-release phases, a final release coming after all of them
+// and a development release without pre-release before
+var pep440Phases = map[string]int{"": 3, "a": 0, "b": 1, "rc": 2}
+
+// PEP440Version is a version of a Python package, as specified by PEP 440
+//
+// Versions are kept in their canonical form, 1.0-ALPHA.1 being 1.0a1, and
+// ordered like pip does, see https://peps.python.org/pep-0440/
+type PEP440Version struct {
+	original  string
+	epoch     int
+	release   []int
+	pre       string
+	preNumber int
+	post      int
+	dev       int
+	local     []string
+}
+
+// PEP440 is the scheme of Python packages, registered as "pep440"
+//
+// Constraints are version specifiers, like >=1.0,!=1.2.*,<2.0
+var PEP440 Scheme = pep440Scheme{}
+
+func init() {
+	RegisterScheme("pep440", PEP440)
+}
+
+// Parses a PEP 440 version, accepting every spelling the specification
+// normalizes
+//
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParsePEP440("1.0-ALPHA.1.POST2.dev3")
+//     v.String()
+//     Returns: "1.0a1.post2.dev3"
+func ParsePEP440(version string) (PEP440Version, error) {
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return PEP440Version{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	result := regexpPEP440.FindStringSubmatch(trimmed)
+	if result == nil {
+		return PEP440Version{}, &ParseError{version, ErrInvalidVersion, ""}
+	}
+
+	v := PEP440Version{original: version, post: -1, dev: -1}
+
+	var err error
+	atoi := func(value string) int {
+		number, e := strconv.Atoi(value)
+		if e != nil && value != "" && err == nil {
+			err = &ParseError{version, ErrInvalidVersion, "number out of range " + strconv.Quote(value)}
+		}
+
+		return number
+	}
+
+	v.epoch = atoi(result[1])
+	for _, segment := range strings.Split(result[2], ".") {
+		v.release = append(v.release, atoi(segment))
+	}
+
+	if result[3] != "" {
+		v.pre = pep440PreReleases[strings.ToLower(result[3])]
+		v.preNumber = atoi(result[4])
+	}
+
+	if result[5] != "" {
+		v.post = atoi(result[5])
+	} else if result[6] != "" {
+		v.post = atoi(result[7])
+	}
+
+	if result[8] != "" {
+		v.dev = atoi(result[9])
+	}
+
+	if result[10] != "" {
+		v.local = strings.FieldsFunc(strings.ToLower(result[10]), func(char rune) bool {
+			return char == '-' || char == '_' || char == '.'
+		})
+	}
+
+	if err != nil {
+		return PEP440Version{}, err
+	}
+
+	return v, nil
+}
+
+// Compares two PEP 440 versions
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.ComparePEP440("1.0.post1", "1.0")
+//     Returns: 1
+//
+//     version.ComparePEP440("1!0.9", "2.0")
+//     Returns: 1
+func ComparePEP440(version1, version2 string) int {
+	v1, err1 := ParsePEP440(version1)
+	v2, err2 := ParsePEP440(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the epoch, 0 when not given
+func (self PEP440Version) Epoch() int {
+	return self.epoch
+}
+
+// Return the release segments, []int{1, 2, 0} for 1.2.0rc1
+func (self PEP440Version) Release() []int {
+	return append([]int(nil), self.release...)
+}
+
+// Return the pre-release phase, a, b or rc, and its number, an empty phase
+// meaning a final release
+func (self PEP440Version) Pre() (string, int) {
+	return self.pre, self.preNumber
+}
+
+// Return the post-release number, -1 when not a post-release
+func (self PEP440Version) Post() int {
+	return self.post
+}
+
+// Return the development release number, -1 when not a development release
+func (self PEP440Version) Dev() int {
+	return self.dev
+}
+
+// Return the local version label, ubuntu.1 for 1.0+ubuntu-1
+func (self PEP440Version) Local() string {
+	return strings.Join(self.local, ".")
+}
+
+// Return true for pre-releases and development releases
+func (self PEP440Version) IsPrerelease() bool {
+	return self.pre != "" || self.dev >= 0
+}
+
+// Return the string given to ParsePEP440
+func (self PEP440Version) Original() string {
+	return self.original
+}
+
+// Return the public version, without local label
+func (self PEP440Version) Public() string {
+	public := self
+	public.local = nil
+
+	return public.String()
+}
+
+// Return the epoch and release, without pre, post, development release or
+// local label
+func (self PEP440Version) BaseVersion() string {
+	return self.base().String()
+}
+
+func (self PEP440Version) base() PEP440Version {
+	return PEP440Version{epoch: self.epoch, release: self.release, post: -1, dev: -1}
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self PEP440Version) Compare(other PEP440Version) int {
+	if cmp := compareInt(self.epoch, other.epoch); cmp != 0 {
+		return cmp
+	}
+
+	// trailing zeros are not significant, 1.0 == 1.0.0
+	for i := 0; i < len(self.release) || i < len(other.release); i++ {
+		if cmp := compareInt(segmentAt(self.release, i), segmentAt(other.release, i)); cmp != 0 {
+			return cmp
+		}
+	}
+
+	if cmp := compareInt(self.phase(), other.phase()); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareInt(self.preNumber, other.preNumber); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := compareInt(self.post, other.post); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := compareInt(devKey(self.dev), devKey(other.dev)); cmp != 0 {
+		return cmp
+	}
+
+	return compareLocal(self.local, other.local)
+}
+
+// Return true if both versions are equal
+func (self PEP440Version) Equal(other PEP440Version) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self PEP440Version) Less(other PEP440Version) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the canonical form of the version
+func (self PEP440Version) String() string {
+	version := ""
+	if self.epoch != 0 {
+		version = strconv.Itoa(self.epoch) + "!"
+	}
+
+	release := make([]string, len(self.release))
+	for i, segment := range self.release {
+		release[i] = strconv.Itoa(segment)
+	}
+	version = version + strings.Join(release, ".")
+
+	if self.pre != "" {
+		version = version + self.pre + strconv.Itoa(self.preNumber)
+	}
+
+	if self.post >= 0 {
+		version = version + ".post" + strconv.Itoa(self.post)
+	}
+
+	if self.dev >= 0 {
+		version = version + ".dev" + strconv.Itoa(self.dev)
+	}
+
+	if len(self.local) > 0 {
+		version = version + "+" + strings.Join(self.local, ".")
+	}
+
+	return version
+}
+
+// a development release of a final release, like 1.0.dev1, comes before its
+// pre-releases
+func (self PEP440Version) phase() int {
+	if self.pre == "" && self.post < 0 && self.dev >= 0 {
+		return -1
+	}
+
+	return pep440Phases[self.pre]
+}
+
+func segmentAt(segments []int, i int) int {
+	if i < len(segments) {
+		return segments[i]
+	}
+
+	return 0
+}
+
+// a release comes after all of its development releases
+func devKey(dev int) int {
+	if dev < 0 {
+		return math.MaxInt
+	}
+
+	return dev
+}
+
+// numeric segments come after alphanumeric ones, a longer label after its
+// prefixes
+func compareLocal(v1, v2 []string) int {
+	for i := 0; i < len(v1) && i < len(v2); i++ {
+		numeric1, numeric2 := isDigits(v1[i]), isDigits(v2[i])
+
+		var cmp int
+		switch {
+		case numeric1 && numeric2:
+			cmp = compareIdentifier(trimZeros(v1[i]), trimZeros(v2[i]))
+		case numeric1:
+			cmp = 1
+		case numeric2:
+			cmp = -1
+		default:
+			cmp = strings.Compare(v1[i], v2[i])
+		}
+
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareInt(len(v1), len(v2))
+}
+
+var regexpPEP440Specifier = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*(\S+)$`)
+
+// PEP440Specifier is a set of PEP 440 version specifiers, like
+// >=1.0,!=1.2.*,<2.0, a version matching when it satisfies all of them
+type PEP440Specifier struct {
+	clauses []pep440Clause
+}
+
+type pep440Clause struct {
+	operator string
+	raw      string
+	version  PEP440Version
+	prefix   bool
+}
+
+// Parses a comma separated set of PEP 440 version specifiers
+//
+// The operators are ~=, ==, !=, <=, >=, <, > and ===, == and != accepting a
+// trailing .* for prefix matching. Malformed specifiers are reported as a
+// *ConstraintError.
+//
+// Usage
+//     s, _ := version.ParsePEP440Specifier("~=1.4.2, !=1.4.5")
+//     s.Match("1.4.9")
+//     Returns: true
+func ParsePEP440Specifier(specifier string) (*PEP440Specifier, error) {
+	set := new(PEP440Specifier)
+	if strings.TrimSpace(specifier) == "" {
+		return set, nil
+	}
+
+	start := 0
+	for _, text := range strings.Split(specifier, ",") {
+		offset := start + len(text) - len(strings.TrimLeft(text, " \t"))
+		start += len(text) + 1
+
+		text = strings.TrimSpace(text)
+		clause, err := parsePEP440Clause(text)
+		if err != nil {
+			return nil, &ConstraintError{specifier, text, offset, err}
+		}
+
+		set.clauses = append(set.clauses, clause)
+	}
+
+	return set, nil
+}
+
+func parsePEP440Clause(text string) (pep440Clause, error) {
+	if text == "" {
+		return pep440Clause{}, ErrEmptyConstraint
+	}
+
+	result := regexpPEP440Specifier.FindStringSubmatch(text)
+	if result == nil {
+		return pep440Clause{}, ErrInvalidConstraint
+	}
+
+	clause := pep440Clause{operator: result[1], raw: result[2]}
+	if clause.operator == "===" {
+		// arbitrary equality is a plain string comparison
+		clause.version, _ = ParsePEP440(clause.raw)
+
+		return clause, nil
+	}
+
+	version := result[2]
+	if clause.operator == "==" || clause.operator == "!=" {
+		if strings.HasSuffix(version, ".*") {
+			version = strings.TrimSuffix(version, ".*")
+			clause.prefix = true
+		}
+	}
+
+	v, err := ParsePEP440(version)
+	if err != nil {
+		return pep440Clause{}, err
+	}
+	clause.version = v
+
+	switch {
+	case clause.prefix && (v.IsPrerelease() || v.post >= 0 || len(v.local) > 0):
+		return pep440Clause{}, ErrInvalidConstraint
+	case len(v.local) > 0 && clause.operator != "==" && clause.operator != "!=":
+		return pep440Clause{}, ErrInvalidConstraint
+	case clause.operator == "~=" && len(v.release) < 2:
+		return pep440Clause{}, ErrInvalidConstraint
+	}
+
+	return clause, nil
+}
+
+// Match a given version againts the specifiers
+//
+// Like pip, pre-releases and development releases only match when one of
+// the specifiers names one, >=1.0 not matching 2.0b1 while >=1.0b1 does.
+func (self *PEP440Specifier) Match(version string) bool {
+	v, err := ParsePEP440(version)
+	if err != nil {
+		for _, clause := range self.clauses {
+			if clause.operator != "===" || !strings.EqualFold(clause.raw, strings.TrimSpace(version)) {
+				return false
+			}
+		}
+
+		return len(self.clauses) > 0
+	}
+
+	if v.IsPrerelease() && !self.allowsPrereleases() {
+		return false
+	}
+
+	for _, clause := range self.clauses {
+		if !clause.match(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self *PEP440Specifier) allowsPrereleases() bool {
+	for _, clause := range self.clauses {
+		if clause.operator != "!=" && clause.version.IsPrerelease() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the specifiers as a comma separated string
+func (self *PEP440Specifier) String() string {
+	clauses := make([]string, len(self.clauses))
+	for i, clause := range self.clauses {
+		clauses[i] = clause.operator + clause.raw
+	}
+
+	return strings.Join(clauses, ",")
+}
+
+func (self pep440Clause) match(v PEP440Version) bool {
+	spec := self.version
+
+	switch self.operator {
+	case "===":
+		return strings.EqualFold(self.raw, strings.TrimSpace(v.original))
+	case "~=":
+		prefix := spec.base()
+		prefix.release = spec.release[:len(spec.release)-1]
+
+		return publicVersion(v).Compare(spec) >= 0 && matchPEP440Prefix(v, prefix)
+	case "==":
+		return self.matchEqual(v)
+	case "!=":
+		return !self.matchEqual(v)
+	case "<=":
+		return publicVersion(v).Compare(spec) <= 0
+	case ">=":
+		return publicVersion(v).Compare(spec) >= 0
+	case "<":
+		// <1.0 does not match 1.0rc1, unless it is itself a pre-release
+		if v.Compare(spec) >= 0 {
+			return false
+		}
+
+		return spec.IsPrerelease() || !v.IsPrerelease() || !sameBase(v, spec)
+	case ">":
+		// >1.0 does not match 1.0.post1 nor 1.0+local, unless it is itself
+		// a post-release
+		if v.Compare(spec) <= 0 {
+			return false
+		}
+
+		if spec.post < 0 && v.post >= 0 && sameBase(v, spec) {
+			return false
+		}
+
+		return len(v.local) == 0 || !sameBase(v, spec)
+	}
+
+	return false
+}
+
+func (self pep440Clause) matchEqual(v PEP440Version) bool {
+	if self.prefix {
+		return matchPEP440Prefix(v, self.version)
+	}
+
+	// local labels are ignored unless the specifier has one
+	if len(self.version.local) == 0 {
+		v = publicVersion(v)
+	}
+
+	return v.Compare(self.version) == 0
+}
+
+// tells if the release of v starts with the one of prefix, release segments
+// being padded with zeros
+func matchPEP440Prefix(v, prefix PEP440Version) bool {
+	if v.epoch != prefix.epoch {
+		return false
+	}
+
+	for i, segment := range prefix.release {
+		if segmentAt(v.release, i) != segment {
+			return false
+		}
+	}
+
+	return true
+}
+
+func publicVersion(v PEP440Version) PEP440Version {
+	v.local = nil
+
+	return v
+}
+
+func sameBase(v1, v2 PEP440Version) bool {
+	return v1.base().Compare(v2.base()) == 0
+}
+
+type pep440Scheme struct{}
+
+func (pep440Scheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParsePEP440(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (pep440Scheme) Compare(version1, version2 string) int {
+	return ComparePEP440(version1, version2)
+}
+
+func (pep440Scheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(PEP440Version).Compare(v2.(PEP440Version))
+}
+
+func (pep440Scheme) ParseConstraint(constraint string) (Matcher, error) {
+	specifier, err := ParsePEP440Specifier(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return specifier, nil
+}
+
+func (pep440Scheme) Format(version string) (string, error) {
+	v, err := ParsePEP440(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
@@ -0,0 +1,204 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var pep440Values = map[string]string{
+	"1.0":                    "1.0",
+	"v1.0":                   "1.0",
+	"1.0.0":                  "1.0.0",
+	"1!1.0":                  "1!1.0",
+	"0!1.0":                  "1.0",
+	"1.0-ALPHA.1":            "1.0a1",
+	"1.0a":                   "1.0a0",
+	"1.0b2":                  "1.0b2",
+	"1.0-preview_3":          "1.0rc3",
+	"1.0c1":                  "1.0rc1",
+	"1.0.post1":              "1.0.post1",
+	"1.0-1":                  "1.0.post1",
+	"1.0rev":                 "1.0.post0",
+	"1.0-r4":                 "1.0.post4",
+	"2.0.dev3":               "2.0.dev3",
+	"2.0-dev":                "2.0.dev0",
+	"1.0a1.post2.dev3":       "1.0a1.post2.dev3",
+	"1.0+ubuntu-1":           "1.0+ubuntu.1",
+	"1.0+Ubuntu_1.A":         "1.0+ubuntu.1.a",
+	"01.002":                 "1.2",
+	" 1.0 ":                  "1.0",
+	"1.0.post1.dev2+local.7": "1.0.post1.dev2+local.7",
+}
+
+func TestParsePEP440(t *testing.T) {
+	for in, out := range pep440Values {
+		v, err := ParsePEP440(in)
+		if err != nil || v.String() != out || v.Original() != in {
+			t.Errorf("FAIL: ParsePEP440(%v) = %v, %v: want %v", in, v, err, out)
+		}
+
+		if x, err := PEP440.Format(in); x != out || err != nil {
+			t.Errorf("FAIL: PEP440.Format(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"1.0-foo", "a1.0", "1.0+", "1.0+local..1", "1.0.", "1.0a1a2", "1!!1.0", "99999999999999999999"} {
+		if v, err := ParsePEP440(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParsePEP440(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+func TestPEP440Parts(t *testing.T) {
+	v, _ := ParsePEP440("2!1.2.0rc3.post4.dev5+ubuntu.1")
+	pre, number := v.Pre()
+
+	if v.Epoch() != 2 || len(v.Release()) != 3 || pre != "rc" || number != 3 || v.Post() != 4 || v.Dev() != 5 ||
+		v.Local() != "ubuntu.1" || !v.IsPrerelease() {
+		t.Errorf("FAIL: ParsePEP440(2!1.2.0rc3.post4.dev5+ubuntu.1) = %+v", v)
+	}
+
+	if x := v.Public(); x != "2!1.2.0rc3.post4.dev5" {
+		t.Errorf("FAIL: Public() = %v: want %v", x, "2!1.2.0rc3.post4.dev5")
+	}
+
+	if x := v.BaseVersion(); x != "2!1.2.0" {
+		t.Errorf("FAIL: BaseVersion() = %v: want %v", x, "2!1.2.0")
+	}
+}
+
+// ordered as listed by PEP 440, each one lower than the next
+var pep440Ordering = []string{
+	"1.0.dev456",
+	"1.0a1",
+	"1.0a2.dev456",
+	"1.0a12.dev456",
+	"1.0a12",
+	"1.0b1.dev456",
+	"1.0b2",
+	"1.0b2.post345.dev456",
+	"1.0b2.post345",
+	"1.0rc1.dev456",
+	"1.0rc1",
+	"1.0",
+	"1.0+abc.5",
+	"1.0+abc.7",
+	"1.0+5",
+	"1.0.post456.dev34",
+	"1.0.post456",
+	"1.0.15",
+	"1.1.dev1",
+	"1!0.1",
+}
+
+func TestComparePEP440(t *testing.T) {
+	for i := range pep440Ordering {
+		for j := range pep440Ordering {
+			out := compareInt(i, j)
+			if x := ComparePEP440(pep440Ordering[i], pep440Ordering[j]); x != out {
+				t.Errorf("FAIL: ComparePEP440(%v, %v) = %v: want %v", pep440Ordering[i], pep440Ordering[j], x, out)
+			}
+		}
+	}
+
+	for in, out := range map[string]int{"1.0|1.0.0": 0, "1.0a|1.0a0": 0, "1.0-1|1.0.post1": 0, "1.0+A|1.0+a": 0, "banana|1.0": -1} {
+		v := strings.Split(in, "|")
+		if x := PEP440.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: PEP440.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var pep440Specifiers = map[string]bool{
+	"~=1.4.2|1.4.2":            true,
+	"~=1.4.2|1.4.9":            true,
+	"~=1.4.2|1.5":              false,
+	"~=1.4.2|1.4.1":            false,
+	"~=1.4|1.9":                true,
+	"~=1.4|2.0":                false,
+	"~=2.2.post3|2.2.post3":    true,
+	"~=2.2.post3|2.2":          false,
+	"~=2.2.post3|2.9":          true,
+	"~=1.4.5.0|1.4.5.9":        true,
+	"~=1.4.5.0|1.4.6":          false,
+	"==1.1|1.1.0":              true,
+	"==1.1|1.1+local":          true,
+	"==1.1+local|1.1":          false,
+	"==1.1+local|1.1+local":    true,
+	"==1.1.*|1.1.post1":        true,
+	"==1.1.*|1.1a1":            false,
+	"==1.1.*,>=1.1a1|1.1a1":    true,
+	"==1.1.*|1.10":             false,
+	"==1.1.*|1.1":              true,
+	"==1.1.*|1!1.1":            false,
+	"!=1.2.*|1.2.5":            false,
+	"!=1.2.*|1.3":              true,
+	"!=1.0|1.0+local":          false,
+	">=1.0,!=1.2.*,<2.0|1.5":   true,
+	">=1.0,!=1.2.*,<2.0|1.2.1": false,
+	">=1.0,!=1.2.*,<2.0|2.0":   false,
+	">=1.0|2.0b1":              false,
+	">=1.0b1|2.0b1":            true,
+	">=1.0|2.0.dev1":           false,
+	"<=1.0|1.0+local":          true,
+	"<1.0|1.0rc1":              false,
+	"<1.0rc2|1.0rc1":           true,
+	"<1.0|0.9":                 true,
+	">1.0|1.0.post1":           false,
+	">1.0|1.0+local":           false,
+	">1.0|1.0.1":               true,
+	">1.0.post1|1.0.post2":     true,
+	"===1.0|1.0":               true,
+	"===1.0|1.0.0":             false,
+	"===foobar|FooBar":         true,
+	"|1.0":                     true,
+	"|1.0rc1":                  false,
+}
+
+func TestParsePEP440Specifier(t *testing.T) {
+	for in, out := range pep440Specifiers {
+		tmp := strings.Split(in, "|")
+
+		s, err := ParsePEP440Specifier(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParsePEP440Specifier(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := s.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var pep440SpecifierErrors = map[string]error{
+	">=1.0,":       ErrEmptyConstraint,
+	"1.0":          ErrInvalidConstraint,
+	"=>1.0":        ErrInvalidConstraint,
+	"~=1":          ErrInvalidConstraint,
+	">=1.0+local":  ErrInvalidConstraint,
+	"==1.0+a.*":    ErrInvalidConstraint,
+	"==1.0rc1.*":   ErrInvalidConstraint,
+	">=1.0, <2.x":  ErrInvalidVersion,
+	">=1.0, <=1.*": ErrInvalidVersion,
+}
+
+func TestParsePEP440SpecifierErrors(t *testing.T) {
+	for in, out := range pep440SpecifierErrors {
+		s, err := PEP440.ParseConstraint(in)
+		if s != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: PEP440.ParseConstraint(%v) = %v, %v: want error %v", in, s, err, out)
+		}
+	}
+
+	_, err := ParsePEP440Specifier(">=1.0, <2.x")
+	if e, ok := err.(*ConstraintError); !ok || e.Clause != "<2.x" || e.Offset != 7 {
+		t.Errorf("FAIL: ParsePEP440Specifier(>=1.0, <2.x) error = %#v: want clause <2.x at offset 7", err)
+	}
+
+	s, _ := ParsePEP440Specifier(">= 1.0, !=1.2.*")
+	if x := s.String(); x != ">=1.0,!=1.2.*" {
+		t.Errorf("FAIL: String() = %v: want %v", x, ">=1.0,!=1.2.*")
+	}
+}
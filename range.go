@@ -0,0 +1,548 @@
+package version
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Range is a set of versions, made of disjoint intervals ordered by a scheme
+//
+// Ranges are built from constraint groups and combined with Intersect, Union
+// and Complement, each of them returning a new normalized range: intervals
+// are sorted, merged when they overlap or touch, and empty ones dropped.
+// Ranges of different schemes share no version: combining them gives an empty
+// range and comparing them false.
+//
+// Dialects matching pre-releases apart from releases, like npm, Cargo and
+// Terraform, give split ranges: releases and pre-releases are held in two
+// sets of intervals, each combined with its counterpart.
+type Range struct {
+	scheme    Scheme
+	intervals []interval
+
+	// when split, intervals only holds releases and prereleases the
+	// pre-releases
+	split       bool
+	prereleases []interval
+}
+
+// schemes whose constraints match pre-releases apart from releases
+type prereleaseScheme interface {
+	// Return the release a pre-release comes before, 1.2.3 for 1.2.3-beta,
+	// and false when version is not a pre-release
+	releaseOf(version string) (string, bool)
+}
+
+type interval struct {
+	low  bound
+	high bound
+}
+
+// end of an interval, an unbounded low bound being below any version and an
+// unbounded high bound above any version
+type bound struct {
+	version   string
+	inclusive bool
+	unbounded bool
+}
+
+var noBound = bound{unbounded: true}
+
+// Return the set of versions matched by the group
+//
+// The constraints of the group are intersected, then intersected with the
+// union of its alternatives. Versions are compared with the scheme of the
+// group, Composer for groups made by NewConstrainGroupFromString, and the
+// rules of the dialect on pre-releases are taken into account.
+//
+// Usage
+//     r := version.NewConstrainGroupFromString(">=1.0, <2.0 || >=1.5, <3.0").Range()
+//     r.String()
+//     Returns: ">=1.0.0.0, <3.0.0.0-dev"
+func (self *ConstraintGroup) Range() *Range {
+	scheme := self.scheme
+	if scheme == nil {
+		scheme = Composer
+	}
+
+	result := &Range{scheme: scheme, intervals: []interval{{noBound, noBound}}}
+	if self.admitted != nil {
+		result = self.admitted(self.constraints)
+	}
+
+	for _, constraint := range self.constraints {
+		result = result.Intersect(constraint.rangeOf(scheme))
+	}
+
+	if len(self.alternatives) == 0 {
+		return result
+	}
+
+	alternatives := &Range{scheme: scheme}
+	for _, alternative := range self.alternatives {
+		alternatives = alternatives.Union(alternative.Range())
+	}
+
+	return result.Intersect(alternatives)
+}
+
+// Return true if every version matched by the group is matched by other,
+// computed over their ranges
+//
+// False when the groups use different schemes.
+//
+// Usage
+//     a := version.NewConstrainGroupFromString("~1.2.3")
+//     a.IsSubsetOf(version.NewConstrainGroupFromString("^1.2"))
+//     Returns: true
+func (self *ConstraintGroup) IsSubsetOf(other *ConstraintGroup) bool {
+	return self.Range().IsSubsetOf(other.Range())
+}
+
+// Return true if a version is matched by both groups, computed over their
+// ranges
+//
+// False when the groups use different schemes.
+//
+// Usage
+//     a := version.NewConstrainGroupFromString("~1.2")
+//     a.Overlaps(version.NewConstrainGroupFromString(">=1.9, <3.0"))
+//     Returns: true
+func (self *ConstraintGroup) Overlaps(other *ConstraintGroup) bool {
+	return self.Range().Overlaps(other.Range())
+}
+
+// Return true if the group matches every version matched by other, false
+// when the groups use different schemes
+func (self *ConstraintGroup) AllowsAll(other *ConstraintGroup) bool {
+	return other.IsSubsetOf(self)
+}
+
+// Return true if the group matches any version matched by other, false when
+// the groups use different schemes
+func (self *ConstraintGroup) AllowsAny(other *ConstraintGroup) bool {
+	return self.Overlaps(other)
+}
+
+// the set of versions matched by a constraint
+func (self *Constraint) rangeOf(scheme Scheme) *Range {
+	at := bound{version: self.version, inclusive: true}
+	after := bound{version: self.version}
+
+	var intervals []interval
+	switch self.operator {
+	case ">", "gt":
+		intervals = []interval{{after, noBound}}
+	case ">=", "ge":
+		intervals = []interval{{at, noBound}}
+	case "<=", "le":
+		intervals = []interval{{noBound, at}}
+	case "==", "=", "eq":
+		intervals = []interval{{at, at}}
+	case "<>", "!=", "ne":
+		intervals = []interval{{noBound, after}, {after, noBound}}
+	case "", "<", "lt":
+		intervals = []interval{{noBound, after}}
+	}
+
+	return newRange(scheme, intervals)
+}
+
+// sorts and merges intervals into a range
+func newRange(scheme Scheme, intervals []interval) *Range {
+	result := &Range{scheme: scheme}
+	result.intervals = result.merge(intervals)
+
+	return result
+}
+
+// sorts and merges releases and pre-releases into a split range
+func newSplitRange(scheme Scheme, releases, prereleases []interval) *Range {
+	result := &Range{scheme: scheme, split: true}
+	result.intervals = result.merge(result.snap(releases))
+	result.prereleases = result.merge(prereleases)
+
+	return result
+}
+
+// sorts intervals, merging the ones that overlap or touch and dropping the
+// empty ones
+func (self *Range) merge(intervals []interval) []interval {
+	sorted := make([]interval, 0, len(intervals))
+	for _, current := range intervals {
+		if !self.isEmpty(current) {
+			sorted = append(sorted, current)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return self.compareLow(sorted[i].low, sorted[j].low) < 0
+	})
+
+	merged := make([]interval, 0, len(sorted))
+	for _, current := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && self.touches(merged[last].high, current.low) {
+			if self.compareHigh(current.high, merged[last].high) > 0 {
+				merged[last].high = current.high
+			}
+			continue
+		}
+
+		merged = append(merged, current)
+	}
+
+	return merged
+}
+
+// moves the pre-release bounds of intervals of releases to the releases
+// they come before, a range of releases from 1.2.4-beta up to 1.2.4 holding
+// no version
+func (self *Range) snap(intervals []interval) []interval {
+	scheme, ok := self.scheme.(prereleaseScheme)
+	if !ok {
+		return intervals
+	}
+
+	snapped := make([]interval, len(intervals))
+	for i, current := range intervals {
+		if release, ok := scheme.releaseOf(current.low.version); ok && !current.low.unbounded {
+			current.low = bound{version: release, inclusive: true}
+		}
+		if release, ok := scheme.releaseOf(current.high.version); ok && !current.high.unbounded {
+			current.high = bound{version: release}
+		}
+
+		snapped[i] = current
+	}
+
+	return snapped
+}
+
+// the pre-releases of the range, the same intervals than releases when not
+// split
+func (self *Range) prereleaseIntervals() []interval {
+	if self.split {
+		return self.prereleases
+	}
+
+	return self.intervals
+}
+
+// builds a range of the scheme of self from releases and pre-releases,
+// split when any of self or other is
+func (self *Range) combine(other *Range, releases, prereleases []interval) *Range {
+	if !self.split && !other.split {
+		return newRange(self.scheme, releases)
+	}
+
+	return newSplitRange(self.scheme, releases, prereleases)
+}
+
+// Return the versions in both ranges, none when the ranges use different
+// schemes
+//
+// Usage
+//     a := version.NewConstrainGroupFromString("~1.2").Range()
+//     b := version.NewConstrainGroupFromString(">=1.5").Range()
+//     a.Intersect(b).String()
+//     Returns: ">=1.5.0.0, <2.0.0.0-dev"
+func (self *Range) Intersect(other *Range) *Range {
+	if !self.sameScheme(other) {
+		return newRange(self.scheme, nil)
+	}
+
+	return self.combine(other,
+		self.intersect(self.intervals, other.intervals),
+		self.intersect(self.prereleaseIntervals(), other.prereleaseIntervals()))
+}
+
+func (self *Range) intersect(a, b []interval) []interval {
+	intervals := make([]interval, 0)
+	for _, current := range a {
+		for _, other := range b {
+			overlap := current
+			if self.compareLow(other.low, overlap.low) > 0 {
+				overlap.low = other.low
+			}
+			if self.compareHigh(other.high, overlap.high) < 0 {
+				overlap.high = other.high
+			}
+
+			intervals = append(intervals, overlap)
+		}
+	}
+
+	return intervals
+}
+
+// Return the versions in any of the ranges, none when the ranges use
+// different schemes
+//
+// Usage
+//     a := version.NewConstrainGroupFromString("~1.2").Range()
+//     b := version.NewConstrainGroupFromString(">=1.5").Range()
+//     a.Union(b).String()
+//     Returns: ">=1.2.0.0"
+func (self *Range) Union(other *Range) *Range {
+	if !self.sameScheme(other) {
+		return newRange(self.scheme, nil)
+	}
+
+	return self.combine(other,
+		append(append([]interval{}, self.intervals...), other.intervals...),
+		append(append([]interval{}, self.prereleaseIntervals()...), other.prereleaseIntervals()...))
+}
+
+// Return the versions not in the range
+//
+// Usage
+//     r := version.NewConstrainGroupFromString("~1.2").Range()
+//     r.Complement().String()
+//     Returns: "<1.2.0.0 || >=2.0.0.0-dev"
+func (self *Range) Complement() *Range {
+	if !self.split {
+		return newRange(self.scheme, self.complement(self.intervals))
+	}
+
+	return newSplitRange(self.scheme, self.complement(self.intervals), self.complement(self.prereleases))
+}
+
+func (self *Range) complement(intervals []interval) []interval {
+	complement := make([]interval, 0, len(intervals)+1)
+
+	low := noBound
+	for _, current := range intervals {
+		if !current.low.unbounded {
+			high := current.low
+			high.inclusive = !high.inclusive
+			complement = append(complement, interval{low, high})
+		}
+
+		if current.high.unbounded {
+			return complement
+		}
+
+		low = current.high
+		low.inclusive = !low.inclusive
+	}
+
+	return append(complement, interval{low, noBound})
+}
+
+// Return true if no version is in the range
+func (self *Range) IsEmpty() bool {
+	return len(self.intervals) == 0 && len(self.prereleaseIntervals()) == 0
+}
+
+// Return true if every version is in the range
+func (self *Range) IsAny() bool {
+	return isAny(self.intervals) && isAny(self.prereleaseIntervals())
+}
+
+func isAny(intervals []interval) bool {
+	return len(intervals) == 1 && intervals[0].low.unbounded && intervals[0].high.unbounded
+}
+
+// Return true if both ranges hold the same versions, bounds being compared
+// with the scheme, so >=1.0 equals >=1.0.0
+//
+// False when the ranges use different schemes.
+func (self *Range) Equal(other *Range) bool {
+	if !self.sameScheme(other) {
+		return false
+	}
+
+	return self.equal(self.intervals, other.intervals) &&
+		self.equal(self.prereleaseIntervals(), other.prereleaseIntervals())
+}
+
+func (self *Range) equal(a, b []interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, current := range a {
+		if self.compareLow(current.low, b[i].low) != 0 ||
+			self.compareHigh(current.high, b[i].high) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return true if every version of the range is in other, false when the
+// ranges use different schemes
+func (self *Range) IsSubsetOf(other *Range) bool {
+	return self.sameScheme(other) && self.Intersect(other.Complement()).IsEmpty()
+}
+
+// Return true if a version is in both ranges, false when the ranges use
+// different schemes
+func (self *Range) Overlaps(other *Range) bool {
+	return !self.Intersect(other).IsEmpty()
+}
+
+// bounds of other are compared with the scheme of self, so both must be the
+// same; schemes holding values that can not be compared with == are never
+// the same, as comparing them would panic
+func (self *Range) sameScheme(other *Range) bool {
+	a, b := reflect.ValueOf(self.scheme), reflect.ValueOf(other.scheme)
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	return a.Type() == b.Type() && a.Comparable() && self.scheme == other.scheme
+}
+
+// Return true if the version is in the range
+func (self *Range) Match(version string) bool {
+	intervals := self.intervals
+	if scheme, ok := self.scheme.(prereleaseScheme); ok && self.split {
+		if _, prerelease := scheme.releaseOf(version); prerelease {
+			intervals = self.prereleases
+		}
+	}
+
+	point := bound{version: version, inclusive: true}
+	for _, current := range intervals {
+		if self.compareLow(current.low, point) <= 0 && self.compareHigh(current.high, point) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the range as a constraint, intervals being alternatives, * when it
+// holds every version and an empty string when it holds none
+//
+// Like the dialects they come from, split ranges are written as their
+// releases, followed by the pre-releases they hold if any, as in
+// ">=1.2.3, <1.3.0; pre-releases: >=1.2.3-beta, <1.2.3".
+func (self *Range) String() string {
+	if !self.split || len(self.prereleases) == 0 {
+		return self.format(self.intervals)
+	}
+
+	prereleases := "pre-releases: " + self.format(self.prereleases)
+	if len(self.intervals) == 0 {
+		return prereleases
+	}
+
+	return self.format(self.intervals) + "; " + prereleases
+}
+
+func (self *Range) format(intervals []interval) string {
+	if isAny(intervals) {
+		return "*"
+	}
+
+	alternatives := make([]string, len(intervals))
+	for i, current := range intervals {
+		if !current.low.unbounded && !current.high.unbounded &&
+			current.low.inclusive && current.high.inclusive &&
+			self.scheme.Compare(current.low.version, current.high.version) == 0 {
+			alternatives[i] = "==" + current.low.version
+			continue
+		}
+
+		constraints := make([]string, 0, 2)
+		if !current.low.unbounded {
+			operator := ">"
+			if current.low.inclusive {
+				operator = ">="
+			}
+			constraints = append(constraints, operator+current.low.version)
+		}
+
+		if !current.high.unbounded {
+			operator := "<"
+			if current.high.inclusive {
+				operator = "<="
+			}
+			constraints = append(constraints, operator+current.high.version)
+		}
+
+		alternatives[i] = strings.Join(constraints, ", ")
+	}
+
+	return strings.Join(alternatives, " || ")
+}
+
+// compares low bounds, the lowest one starting first
+func (self *Range) compareLow(a, b bound) int {
+	switch {
+	case a.unbounded && b.unbounded:
+		return 0
+	case a.unbounded:
+		return -1
+	case b.unbounded:
+		return 1
+	}
+
+	if cmp := self.scheme.Compare(a.version, b.version); cmp != 0 {
+		return cmp
+	}
+
+	// an inclusive bound starts before an exclusive one
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return -1
+	}
+
+	return 1
+}
+
+// compares high bounds, the highest one ending last
+func (self *Range) compareHigh(a, b bound) int {
+	switch {
+	case a.unbounded && b.unbounded:
+		return 0
+	case a.unbounded:
+		return 1
+	case b.unbounded:
+		return -1
+	}
+
+	if cmp := self.scheme.Compare(a.version, b.version); cmp != 0 {
+		return cmp
+	}
+
+	// an inclusive bound ends after an exclusive one
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return 1
+	}
+
+	return -1
+}
+
+// tells if an interval ending at high and one starting at low overlap or
+// touch, leaving no version between them
+func (self *Range) touches(high, low bound) bool {
+	if high.unbounded || low.unbounded {
+		return true
+	}
+
+	cmp := self.scheme.Compare(low.version, high.version)
+
+	return cmp < 0 || cmp == 0 && (low.inclusive || high.inclusive)
+}
+
+// tells if an interval holds no version
+func (self *Range) isEmpty(current interval) bool {
+	if current.low.unbounded || current.high.unbounded {
+		return false
+	}
+
+	cmp := self.scheme.Compare(current.low.version, current.high.version)
+
+	return cmp > 0 || cmp == 0 && !(current.low.inclusive && current.high.inclusive)
+}
@@ -0,0 +1,311 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+var rangeValues = map[string]string{
+	"*":                          "*",
+	">=1.0":                      ">=1.0.0.0",
+	">=1.0, <2.0":                ">=1.0.0.0, <2.0.0.0-dev",
+	"~1.2":                       ">=1.2.0.0, <2.0.0.0-dev",
+	"^1.2.3":                     ">=1.2.3.0, <2.0.0.0-dev",
+	"1.0.2":                      "==1.0.2.0",
+	"!=1.0":                      "<1.0.0.0 || >1.0.0.0",
+	">=1.0, <2.0 || >=1.5, <3.0": ">=1.0.0.0, <3.0.0.0-dev",
+	"~1.2 || ^3.0":               ">=1.2.0.0, <2.0.0.0-dev || >=3.0.0.0, <4.0.0.0-dev",
+	"^3.0 || ~1.2":               ">=1.2.0.0, <2.0.0.0-dev || >=3.0.0.0, <4.0.0.0-dev",
+	"<=1.0 || >=1.0":             "*",
+	"<1.0 || >1.0":               "<1.0.0.0-dev || >1.0.0.0",
+	"<1.0-stable || >=1.0":       "*",
+	">=2.0, <1.0":                "",
+	">1.0, <=1.0":                "",
+	">=1.0, <=1.0":               "==1.0.0.0",
+	">=1.0, !=1.5, <2.0":         ">=1.0.0.0, <1.5.0.0 || >1.5.0.0, <2.0.0.0-dev",
+}
+
+func TestConstraintGroupRange(t *testing.T) {
+	for in, out := range rangeValues {
+		if x := NewConstrainGroupFromString(in).Range().String(); x != out {
+			t.Errorf("FAIL: Range(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var rangeSchemeValues = map[string]string{
+	"debian|>= 1.0, << 2.0":    ">=1.0, <2.0",
+	"npm|^1.2.3 || ^1.5":       ">=1.2.3, <2.0.0",
+	"npm|>=1.2.3-beta <1.3":    ">=1.2.3, <1.3.0; pre-releases: >=1.2.3-beta, <1.2.3",
+	"npm|>=1.2.4-beta <1.2.4":  "pre-releases: >=1.2.4-beta, <1.2.4",
+	"cargo|*":                  "*",
+	"terraform|~> 1.5":         ">=1.5, <2",
+	"terraform|~> 1.5.1-beta1": "pre-releases: >=1.5.1-beta1, <1.5.1",
+	"maven|(,1.0],[1.2,)":      "<=1.0 || >=1.2",
+	"maven|1.0":                "*",
+}
+
+func TestSchemeRange(t *testing.T) {
+	for in, out := range rangeSchemeValues {
+		name, constraint, _ := strings.Cut(in, "|")
+		scheme, _ := LookupScheme(name)
+
+		matcher, err := scheme.ParseConstraint(constraint)
+		if err != nil {
+			t.Errorf("FAIL: %v.ParseConstraint(%v) error = %v", name, constraint, err)
+			continue
+		}
+
+		group, ok := matcher.(*ConstraintGroup)
+		if !ok {
+			t.Errorf("FAIL: %v.ParseConstraint(%v) = %T: want *ConstraintGroup", name, constraint, matcher)
+			continue
+		}
+
+		if x := group.Range().String(); x != out {
+			t.Errorf("FAIL: Range(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var rangeIntersectValues = map[[2]string]string{
+	{"~1.2", ">=1.5"}:                 ">=1.5.0.0, <2.0.0.0-dev",
+	{"~1.2", "^2.0"}:                  "",
+	{"<=1.0", ">=1.0"}:                "==1.0.0.0",
+	{"<1.0", ">=1.0"}:                 "",
+	{"*", "~1.2"}:                     ">=1.2.0.0, <2.0.0.0-dev",
+	{"!=1.5", "~1.2"}:                 ">=1.2.0.0, <1.5.0.0 || >1.5.0.0, <2.0.0.0-dev",
+	{"~1.2 || ^3.0", "~1.5 || >=3.5"}: ">=1.5.0.0, <2.0.0.0-dev || >=3.5.0.0, <4.0.0.0-dev",
+}
+
+var rangeUnionValues = map[[2]string]string{
+	{"~1.2", ">=1.5"}: ">=1.2.0.0",
+	{"~1.2", "^2.0"}:  ">=1.2.0.0, <2.0.0.0-dev || >=2.0.0.0, <3.0.0.0-dev",
+	{"<1.0", ">1.0"}:  "<1.0.0.0-dev || >1.0.0.0",
+	{"<=1.0", ">1.0"}: "*",
+	{"<1.0", "==2.0"}: "<1.0.0.0-dev || ==2.0.0.0",
+	{">=1.0", ""}:     ">=1.0.0.0",
+}
+
+var rangeComplementValues = map[string]string{
+	"~1.2":         "<1.2.0.0 || >=2.0.0.0-dev",
+	"*":            "",
+	">=1.0":        "<1.0.0.0",
+	"<=1.0":        ">1.0.0.0",
+	"!=1.0":        "==1.0.0.0",
+	"~1.2 || ^3.0": "<1.2.0.0 || >=2.0.0.0-dev, <3.0.0.0 || >=4.0.0.0-dev",
+}
+
+func TestRangeAlgebra(t *testing.T) {
+	for in, out := range rangeIntersectValues {
+		a, b := in[0], in[1]
+		if x := NewConstrainGroupFromString(a).Range().Intersect(NewConstrainGroupFromString(b).Range()).String(); x != out {
+			t.Errorf("FAIL: Intersect(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range rangeUnionValues {
+		a, b := in[0], in[1]
+		r := &Range{scheme: Composer}
+		if b != "" {
+			r = NewConstrainGroupFromString(b).Range()
+		}
+
+		if x := NewConstrainGroupFromString(a).Range().Union(r).String(); x != out {
+			t.Errorf("FAIL: Union(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range rangeComplementValues {
+		r := NewConstrainGroupFromString(in).Range()
+		if x := r.Complement().String(); x != out {
+			t.Errorf("FAIL: Complement(%v) = %v: want %v", in, x, out)
+		}
+
+		if !r.Complement().Complement().Equal(r) {
+			t.Errorf("FAIL: Complement(Complement(%v)) = %v: want %v", in, r.Complement().Complement(), r)
+		}
+
+		if !r.Union(r.Complement()).IsAny() || !r.Intersect(r.Complement()).IsEmpty() {
+			t.Errorf("FAIL: Union, Intersect(%v, Complement(%v))", in, in)
+		}
+	}
+}
+
+var rangeEqualValues = map[[2]string]bool{
+	{">=1.0", ">=1.0.0"}:          true,
+	{">=1.0", ">1.0"}:             false,
+	{"~1.2", ">=1.2, <2.0-dev"}:   true,
+	{"~1.2", "^1.2"}:              true,
+	{"~1.2.3", "^1.2.3"}:          false,
+	{"<1.0 || >1.0", "!=1.0"}:     false,
+	{"<=1.0 || >1.0", "*"}:        true,
+	{">=2.0, <1.0", ">1.0, <1.0"}: true,
+}
+
+func TestRangeEqual(t *testing.T) {
+	for in, out := range rangeEqualValues {
+		a, b := in[0], in[1]
+		if x := NewConstrainGroupFromString(a).Range().Equal(NewConstrainGroupFromString(b).Range()); x != out {
+			t.Errorf("FAIL: Equal(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestRangeMatch(t *testing.T) {
+	for _, constraint := range []string{"~1.2", "^0.3 || >=2.0, !=2.1", ">1.0, <=2.0", "!=1.5", "1.0.*"} {
+		group := NewConstrainGroupFromString(constraint)
+		r := group.Range()
+
+		for _, v := range []string{"0.3.5", "1.0", "1.0.1", "1.2.0", "1.5", "1.9.9", "2.0", "2.0.0-beta", "2.1", "3.0"} {
+			if x, out := r.Match(v), group.Match(v); x != out {
+				t.Errorf("FAIL: Range(%v).Match(%v) = %v: want %v", constraint, v, x, out)
+			}
+		}
+	}
+}
+
+var rangeSchemeMatchValues = map[string][]string{
+	"npm":       {"^1.2.3", ">=1.2.3-beta <1.3", "~1.2.4-beta || ^1.5", "1.2.3 - 1.5", "*"},
+	"cargo":     {"^1.2.3", "*", ">=1.2.4-beta, <1.3", "=1.2.3-beta"},
+	"terraform": {"~> 1.5", ">= 1.2.4-beta, < 1.3", "~> 1.5.1-beta1", "= 1.2.3-beta"},
+}
+
+func TestSchemeRangeMatch(t *testing.T) {
+	versions := []string{"1.2.2", "1.2.3-beta", "1.2.3", "1.2.4-beta", "1.2.4", "1.3.0-rc.1", "1.5.0", "1.5.1-beta1", "2.0.0-0", "2.0.0"}
+
+	for name, constraints := range rangeSchemeMatchValues {
+		scheme, _ := LookupScheme(name)
+		for _, constraint := range constraints {
+			matcher, err := scheme.ParseConstraint(constraint)
+			if err != nil {
+				t.Errorf("FAIL: %v.ParseConstraint(%v) error = %v", name, constraint, err)
+				continue
+			}
+
+			group := matcher.(*ConstraintGroup)
+			r := group.Range()
+			for _, v := range versions {
+				if x, out := r.Match(v), group.Match(v); x != out {
+					t.Errorf("FAIL: %v Range(%v).Match(%v) = %v: want %v", name, constraint, v, x, out)
+				}
+			}
+		}
+	}
+}
+
+var rangeSubsetValues = map[[2]string]bool{
+	{"~1.2.3", "^1.2"}:             true,
+	{"^1.2", "~1.2.3"}:             false,
+	{"~1.2", "^1.2"}:               true,
+	{"^1.2", "~1.2"}:               true,
+	{"1.2.5", "~1.2.3"}:            true,
+	{"1.3.0", "~1.2.3"}:            false,
+	{">=1.0", "*"}:                 true,
+	{"*", ">=1.0"}:                 false,
+	{">2.0, <1.0", "1.0"}:          true,
+	{"~1.2 || ^3.0", ">=1.2"}:      true,
+	{"~1.2 || ^3.0", "^1.2"}:       false,
+	{"!=1.5, ~1.2", "~1.2"}:        true,
+	{"~1.2", "!=1.5, ~1.2"}:        false,
+	{"<=2.0", "<2.0"}:              false,
+	{">1.0, <2.0", ">=1.0, <=2.0"}: true,
+}
+
+func TestConstraintGroupIsSubsetOf(t *testing.T) {
+	for in, out := range rangeSubsetValues {
+		a, b := NewConstrainGroupFromString(in[0]), NewConstrainGroupFromString(in[1])
+		if x := a.IsSubsetOf(b); x != out {
+			t.Errorf("FAIL: IsSubsetOf(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := b.AllowsAll(a); x != out {
+			t.Errorf("FAIL: AllowsAll(%v|%v) = %v: want %v", in[1], in[0], x, out)
+		}
+	}
+}
+
+var rangeOverlapValues = map[[2]string]bool{
+	{"~1.2", ">=1.9, <3.0"}:   true,
+	{"~1.2", "^2.0"}:          false,
+	{"<=1.0", ">=1.0"}:        true,
+	{"<1.0", ">=1.0"}:         false,
+	{"~1.2 || ^3.0", "^2.5"}:  false,
+	{"~1.2 || ^3.0", "3.1"}:   true,
+	{"!=1.5", "1.5"}:          false,
+	{"!=1.5", ">=1.5, <=1.5"}: false,
+	{"*", "1.0"}:              true,
+	{">2.0, <1.0", "*"}:       false,
+}
+
+func TestConstraintGroupOverlaps(t *testing.T) {
+	for in, out := range rangeOverlapValues {
+		a, b := NewConstrainGroupFromString(in[0]), NewConstrainGroupFromString(in[1])
+		if x := a.Overlaps(b); x != out {
+			t.Errorf("FAIL: Overlaps(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := b.AllowsAny(a); x != out {
+			t.Errorf("FAIL: AllowsAny(%v|%v) = %v: want %v", in[1], in[0], x, out)
+		}
+	}
+}
+
+func TestSchemeIsSubsetOf(t *testing.T) {
+	a, _ := ParseCargoRequirement("~1.2.3")
+	b, _ := ParseCargoRequirement("1.2")
+	if !a.IsSubsetOf(b) || b.IsSubsetOf(a) || !a.Overlaps(b) {
+		t.Errorf("FAIL: IsSubsetOf, Overlaps(~1.2.3|1.2) with Cargo")
+	}
+
+	c, _ := ParseMavenRange("[1.0,2.0)")
+	d, _ := ParseMavenRange("[2.0,3.0]")
+	if c.Overlaps(d) || !c.IsSubsetOf(c) {
+		t.Errorf("FAIL: IsSubsetOf, Overlaps([1.0,2.0)|[2.0,3.0]) with Maven")
+	}
+
+	e, _ := ParseNPMRange("^1.2.3")
+	f, _ := ParseNPMRange(">=1.2.4-beta <1.2.4")
+	if e.Overlaps(f) || f.IsSubsetOf(e) || e.IsSubsetOf(f) {
+		t.Errorf("FAIL: IsSubsetOf, Overlaps(^1.2.3|>=1.2.4-beta <1.2.4) with npm")
+	}
+
+	g, _ := ParseNPMRangeWithOptions("^1.2.3", NPMOptions{IncludePrerelease: true})
+	if !g.Overlaps(f) || !e.IsSubsetOf(g) || g.IsSubsetOf(e) {
+		t.Errorf("FAIL: IsSubsetOf, Overlaps(^1.2.3|>=1.2.4-beta <1.2.4) with npm including pre-releases")
+	}
+
+	r := f.Range()
+	if !r.Union(r.Complement()).IsAny() || !r.Intersect(r.Complement()).IsEmpty() || !r.Complement().Complement().Equal(r) {
+		t.Errorf("FAIL: Union, Intersect(>=1.2.4-beta <1.2.4, Complement) with npm")
+	}
+}
+
+// a scheme that can not be compared with ==
+type listScheme struct {
+	Scheme
+	versions []string
+}
+
+func TestRangeSchemeMismatch(t *testing.T) {
+	a, _ := ParseNPMRange("^1.2.3")
+	for name, constraint := range map[string]string{"terraform": "~> 1.5", "cargo": "^1.2.3"} {
+		scheme, _ := LookupScheme(name)
+		matcher, _ := scheme.ParseConstraint(constraint)
+		b := matcher.(*ConstraintGroup)
+
+		if a.IsSubsetOf(b) || a.Overlaps(b) || a.AllowsAll(b) || a.AllowsAny(b) {
+			t.Errorf("FAIL: IsSubsetOf, Overlaps(^1.2.3|%v) with npm and %v = true: want false", constraint, name)
+		}
+
+		if r := a.Range(); r.Equal(b.Range()) || !r.Intersect(b.Range()).IsEmpty() || !r.Union(b.Range()).IsEmpty() {
+			t.Errorf("FAIL: Equal, Intersect, Union(^1.2.3|%v) with npm and %v", constraint, name)
+		}
+	}
+
+	c := newRange(listScheme{Composer, []string{"1.0"}}, []interval{{noBound, noBound}})
+	d := newRange(listScheme{Composer, []string{"1.0"}}, []interval{{noBound, noBound}})
+	if c.Equal(d) || c.Overlaps(d) || c.IsSubsetOf(d) {
+		t.Errorf("FAIL: Equal, Overlaps, IsSubsetOf with a scheme that can not be compared = true: want false")
+	}
+}
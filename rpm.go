@@ -0,0 +1,341 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RPMVersion is an RPM epoch, version and release, [epoch:]version[-release]
+//
+// Versions are ordered like rpmvercmp does, ~ sorting before anything and ^
+// after the end of the version but before any other segment.
+type RPMVersion struct {
+	original string
+	epoch    int
+	version  string
+	release  string
+}
+
+// RPM is the scheme of RPM packages, registered as "rpm"
+//
+// Constraints are comma separated relations using the operators <, <=, =, >=
+// and >. A relation without release, like >= 1.0, matches every release.
+var RPM Scheme = rpmScheme{}
+
+var rpmOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"==": "=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+}
+
+func init() {
+	RegisterScheme("rpm", RPM)
+}
+
+// Parses an RPM epoch, version and release string
+//
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseRPM("2:1.4.3-5.el9")
+//     v.Release()
+//     Returns: "5.el9"
+func ParseRPM(version string) (RPMVersion, error) {
+	v := RPMVersion{original: version}
+
+	rest := strings.TrimSpace(version)
+	if rest == "" {
+		return RPMVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := strconv.Atoi(rest[:i])
+		if err != nil || !isDigits(rest[:i]) {
+			return RPMVersion{}, &ParseError{version, ErrInvalidVersion, "invalid epoch " + strconv.Quote(rest[:i])}
+		}
+		v.epoch = epoch
+		rest = rest[i+1:]
+	}
+
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.release = rest[i+1:]
+		if v.release == "" {
+			return RPMVersion{}, &ParseError{version, ErrInvalidVersion, "empty release"}
+		}
+		rest = rest[:i]
+	}
+
+	v.version = rest
+	if v.version == "" {
+		return RPMVersion{}, &ParseError{version, ErrInvalidVersion, "empty version"}
+	}
+
+	if strings.IndexFunc(v.version+v.release, isNotRPMChar) >= 0 {
+		return RPMVersion{}, &ParseError{version, ErrInvalidVersion, "invalid character"}
+	}
+
+	return v, nil
+}
+
+func isNotRPMChar(char rune) bool {
+	return !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+		strings.ContainsRune("._+~^", char))
+}
+
+// Compares two RPM versions, like rpmdev-vercmp
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareRPM("1.0~rc1", "1.0")
+//     Returns: -1
+//
+//     version.CompareRPM("1.0^post1", "1.0")
+//     Returns: 1
+func CompareRPM(version1, version2 string) int {
+	v1, err1 := ParseRPM(version1)
+	v2, err2 := ParseRPM(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Parses comma separated RPM relations, like ">= 1.0, < 2.0"
+//
+// Usage
+//     c, _ := version.ParseRPMConstraint(">= 1.4.3, < 2")
+//     c.Match("2:1.4.3-5.el9")
+//     Returns: false
+func ParseRPMConstraint(constraint string) (*ConstraintGroup, error) {
+	return parseRelations(rpmRelationScheme{}, constraint, rpmOperators)
+}
+
+// Return the epoch, 0 when not given
+func (self RPMVersion) Epoch() int {
+	return self.epoch
+}
+
+// Return the version, without epoch and release
+func (self RPMVersion) Version() string {
+	return self.version
+}
+
+// Return the release, empty when not given
+func (self RPMVersion) Release() string {
+	return self.release
+}
+
+// Return the string given to ParseRPM
+func (self RPMVersion) Original() string {
+	return self.original
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self RPMVersion) Compare(other RPMVersion) int {
+	if cmp := compareInt(self.epoch, other.epoch); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := rpmvercmp(self.version, other.version); cmp != 0 {
+		return cmp
+	}
+
+	return rpmvercmp(self.release, other.release)
+}
+
+// Return true if both versions are equal
+func (self RPMVersion) Equal(other RPMVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self RPMVersion) Less(other RPMVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version, the epoch being left out when 0
+func (self RPMVersion) String() string {
+	version := self.version
+	if self.epoch != 0 {
+		version = strconv.Itoa(self.epoch) + ":" + version
+	}
+
+	if self.release != "" {
+		version = version + "-" + self.release
+	}
+
+	return version
+}
+
+// Compares versions or releases like rpmvercmp
+//
+// Strings are split in runs of digits and runs of letters, anything else
+// being a separator. Numbers are compared numerically and are newer than
+// letters, ~ sorts before anything and ^ before anything but the end.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+
+		// a tilde sorts before everything, even the end
+		if i < len(a) && a[i] == '~' || j < len(b) && b[j] == '~' {
+			if i >= len(a) || a[i] != '~' {
+				return 1
+			}
+			if j >= len(b) || b[j] != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		// a caret sorts after the end, before anything else
+		if i < len(a) && a[i] == '^' || j < len(b) && b[j] == '^' {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if a[i] != '^' {
+				return 1
+			}
+			if b[j] != '^' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		numeric := isDigit(a[i])
+		var end1, end2 int
+		if numeric {
+			end1, end2 = scanDigits(a, i), scanDigits(b, j)
+		} else {
+			end1, end2 = scanLetters(a, i), scanLetters(b, j)
+		}
+
+		// numbers are newer than letters
+		if end2 == j {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		segment1, segment2 := a[i:end1], b[j:end2]
+		if numeric {
+			segment1, segment2 = strings.TrimLeft(segment1, "0"), strings.TrimLeft(segment2, "0")
+			if cmp := compareInt(len(segment1), len(segment2)); cmp != 0 {
+				return cmp
+			}
+		}
+
+		if cmp := strings.Compare(segment1, segment2); cmp != 0 {
+			return cmp
+		}
+
+		i, j = end1, end2
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	}
+
+	return 1
+}
+
+func isAlnum(char byte) bool {
+	return isDigit(char) || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z'
+}
+
+func scanLetters(subject string, from int) int {
+	for from < len(subject) && isAlnum(subject[from]) && !isDigit(subject[from]) {
+		from++
+	}
+
+	return from
+}
+
+type rpmScheme struct{}
+
+func (rpmScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseRPM(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (rpmScheme) Compare(version1, version2 string) int {
+	return CompareRPM(version1, version2)
+}
+
+func (rpmScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(RPMVersion).Compare(v2.(RPMVersion))
+}
+
+func (rpmScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseRPMConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (rpmScheme) Format(version string) (string, error) {
+	v, err := ParseRPM(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
+
+// compares versions given to a relation, where a missing release on either
+// side matches any release, like rpm does for dependencies
+type rpmRelationScheme struct {
+	rpmScheme
+}
+
+func (rpmRelationScheme) Compare(version1, version2 string) int {
+	v1, err1 := ParseRPM(version1)
+	v2, err2 := ParseRPM(version2)
+	if err1 != nil || err2 != nil {
+		return CompareRPM(version1, version2)
+	}
+
+	if v1.release == "" || v2.release == "" {
+		v1.release, v2.release = "", ""
+	}
+
+	return v1.Compare(v2)
+}
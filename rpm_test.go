@@ -0,0 +1,120 @@
+package version
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var rpmValues = map[string][3]string{
+	"1.0":           {"0", "1.0", ""},
+	"1.0-1":         {"0", "1.0", "1"},
+	"2:1.4.3-5.el9": {"2", "1.4.3", "5.el9"},
+	"1.0~rc1^git1":  {"0", "1.0~rc1^git1", ""},
+	"0:1.0-1.fc38":  {"0", "1.0", "1.fc38"},
+}
+
+func TestParseRPM(t *testing.T) {
+	for in, out := range rpmValues {
+		v, err := ParseRPM(in)
+		x := [3]string{strconv.Itoa(v.Epoch()), v.Version(), v.Release()}
+		if err != nil || x != out || v.Original() != in {
+			t.Errorf("FAIL: ParseRPM(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"a:1.0", "1.0-", "-1", "1:", "1.0 1", "1.0/1"} {
+		if v, err := ParseRPM(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseRPM(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// as given by rpmdev-vercmp
+var compareRPMValues = map[string]int{
+	"1.0|1.0":                      0,
+	"1.0|1.1":                      -1,
+	"2.0.1|2.0.1a":                 -1,
+	"5.5p1|5.5p2":                  -1,
+	"5.5p10|5.5p1":                 1,
+	"10xyz|10.1xyz":                -1,
+	"xyz10|xyz10.1":                -1,
+	"xyz.4|8":                      -1,
+	"6.0.rc1|6.0":                  1,
+	"10b2|10a1":                    1,
+	"1.0aa|1.0a":                   1,
+	"10.0001|10.1":                 0,
+	"10.0001|10.0039":              -1,
+	"4.999.9|5.0":                  -1,
+	"20101121|20101122":            -1,
+	"2_0|2_0":                      0,
+	"2.0|2_0":                      0,
+	"a+|a_":                        0,
+	"+_|_+":                        0,
+	"1.0~rc1|1.0":                  -1,
+	"1.0~rc1|1.0~rc2":              -1,
+	"1.0~rc1~git123|1.0~rc1":       -1,
+	"1.0^|1.0":                     1,
+	"1.0^git1|1.0":                 1,
+	"1.0^git1|1.01":                -1,
+	"1.0^20160101|1.0.1":           -1,
+	"1.0~rc1^git1|1.0~rc1":         1,
+	"1.0^git1~pre|1.0^git1":        -1,
+	"1.0-1|1.0-2":                  -1,
+	"1:1.0|2.0":                    1,
+	"2:1.4.3-5.el9|2:1.4.3-10.el9": -1,
+}
+
+func TestCompareRPM(t *testing.T) {
+	for in, out := range compareRPMValues {
+		v := strings.Split(in, "|")
+		if x := CompareRPM(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareRPM(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareRPM(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareRPM(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := RPM.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: RPM.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var rpmConstraints = map[string]bool{
+	">= 1.4.3, < 2|1.4.3-5.el9":   true,
+	">= 1.4.3, < 2|2:1.4.3-5.el9": false,
+	">= 1.4.3-6|1.4.3-5.el9":      false,
+	"= 1.4.3|1.4.3-5.el9":         true,
+	"= 1.4.3-5.el9|1.4.3":         true,
+	"== 1.4.3-4|1.4.3-5":          false,
+	"> 1.0|1.0^post1":             true,
+	"< 1.0|1.0~rc1":               true,
+	"1.0|1.0-1":                   true,
+	"< 3|not a version!":          false,
+	"< 3|":                        false,
+}
+
+func TestParseRPMConstraint(t *testing.T) {
+	for in, out := range rpmConstraints {
+		tmp := strings.Split(in, "|")
+
+		c, err := ParseRPMConstraint(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseRPMConstraint(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := c.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range map[string]error{"": ErrEmptyConstraint, "<< 1.0": ErrInvalidConstraint, ">= 1.0-": ErrInvalidVersion} {
+		if c, err := RPM.ParseConstraint(in); c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: RPM.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+}
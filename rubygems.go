@@ -0,0 +1,363 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var regexpGemVersion = regexp.MustCompile(`^[0-9]+(\.[0-9a-zA-Z]+)*(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// GemVersion is a version of a Ruby gem, ordered like Gem::Version
+//
+// Versions are split in numbers and runs of letters, a version holding any
+// letter being a pre-release: 1.0.0.pre.1 < 1.0.0 and 2.0.b3 < 2.0.
+type GemVersion struct {
+	original string
+	segments []gemSegment
+}
+
+// number without leading zeros or run of letters
+type gemSegment struct {
+	text    string
+	numeric bool
+}
+
+// GemRequirement is a set of requirements on gem versions, like
+// Gem::Requirement, a version matching when it satisfies all of them
+type GemRequirement struct {
+	requirements []*Constraint
+}
+
+// RubyGems is the scheme of Ruby gems, registered as "rubygems"
+//
+// Constraints are gem requirements, like ~> 2.2, != 2.2.3.
+var RubyGems Scheme = rubyGemsScheme{}
+
+var rubyGemsOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"!=": "!=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+	"~>": "~>",
+}
+
+func init() {
+	RegisterScheme("rubygems", RubyGems)
+}
+
+// Parses a gem version
+//
+// Like RubyGems, a - starts a pre-release, 1.0.0-rc1 being 1.0.0.pre.rc1.
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseGem("2.0.b3")
+//     v.IsPrerelease()
+//     Returns: true
+func ParseGem(version string) (GemVersion, error) {
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return GemVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	if !regexpGemVersion.MatchString(trimmed) {
+		return GemVersion{}, &ParseError{version, ErrInvalidVersion, ""}
+	}
+
+	v := GemVersion{original: version}
+
+	trimmed = strings.ReplaceAll(trimmed, "-", ".pre.")
+	for i := 0; i < len(trimmed); {
+		var end int
+		switch {
+		case isDigit(trimmed[i]):
+			end = scanDigits(trimmed, i)
+			number := strings.TrimLeft(trimmed[i:end], "0")
+			if number == "" {
+				number = "0"
+			}
+			v.segments = append(v.segments, gemSegment{number, true})
+		case isAlnum(trimmed[i]):
+			end = scanLetters(trimmed, i)
+			v.segments = append(v.segments, gemSegment{trimmed[i:end], false})
+		default:
+			end = i + 1
+		}
+		i = end
+	}
+
+	return v, nil
+}
+
+// Compares two gem versions, like Gem::Version#<=>
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareGem("1.0.0.pre.1", "1.0.0")
+//     Returns: -1
+//
+//     version.CompareGem("1.0.a", "1.0.a.0")
+//     Returns: 0
+func CompareGem(version1, version2 string) int {
+	v1, err1 := ParseGem(version1)
+	v2, err2 := ParseGem(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Return the string given to ParseGem
+func (self GemVersion) Original() string {
+	return self.original
+}
+
+// Return the numbers and runs of letters of the version, 2.0.b3 being
+// [2 0 b 3]
+func (self GemVersion) Segments() []string {
+	segments := make([]string, len(self.segments))
+	for i, segment := range self.segments {
+		segments[i] = segment.text
+	}
+
+	return segments
+}
+
+// Return true if the version holds any letter
+func (self GemVersion) IsPrerelease() bool {
+	for _, segment := range self.segments {
+		if !segment.numeric {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the release of a pre-release, 1.0.0.pre.1 being 1.0.0, releases
+// being returned as is
+func (self GemVersion) Release() GemVersion {
+	if !self.IsPrerelease() {
+		return self
+	}
+
+	return newGemVersion(self.numbers())
+}
+
+// Return the version following the release, dropping the last number and
+// incrementing the one before it: 2.2.3 being bumped to 2.3 and 2.2 to 3
+func (self GemVersion) Bump() GemVersion {
+	segments := self.numbers()
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+
+	last := len(segments) - 1
+	segments[last] = gemSegment{incrementDigits(segments[last].text), true}
+
+	return newGemVersion(segments)
+}
+
+// the numbers before the first letter
+func (self GemVersion) numbers() []gemSegment {
+	numbers := make([]gemSegment, 0, len(self.segments))
+	for _, segment := range self.segments {
+		if !segment.numeric {
+			break
+		}
+		numbers = append(numbers, segment)
+	}
+
+	return numbers
+}
+
+// segments without the trailing zeros of the numbers and of the pre-release,
+// like Gem::Version#canonical_segments
+func (self GemVersion) canonical() []gemSegment {
+	numbers := self.numbers()
+	prerelease := self.segments[len(numbers):]
+
+	return append(trimGemZeros(numbers), trimGemZeros(prerelease)...)
+}
+
+func trimGemZeros(segments []gemSegment) []gemSegment {
+	end := len(segments)
+	for end > 0 && segments[end-1].numeric && segments[end-1].text == "0" {
+		end--
+	}
+
+	return segments[:end:end]
+}
+
+func newGemVersion(segments []gemSegment) GemVersion {
+	v := GemVersion{segments: segments}
+	v.original = strings.Join(v.Segments(), ".")
+
+	return v
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self GemVersion) Compare(other GemVersion) int {
+	segments1, segments2 := self.canonical(), other.canonical()
+
+	zero := gemSegment{"0", true}
+	for i := 0; i < len(segments1) || i < len(segments2); i++ {
+		segment1, segment2 := zero, zero
+		if i < len(segments1) {
+			segment1 = segments1[i]
+		}
+		if i < len(segments2) {
+			segment2 = segments2[i]
+		}
+
+		// letters are lower than numbers
+		switch {
+		case segment1 == segment2:
+			continue
+		case segment1.numeric && !segment2.numeric:
+			return 1
+		case !segment1.numeric && segment2.numeric:
+			return -1
+		case segment1.numeric:
+			if cmp := compareInt(len(segment1.text), len(segment2.text)); cmp != 0 {
+				return cmp
+			}
+		}
+
+		return strings.Compare(segment1.text, segment2.text)
+	}
+
+	return 0
+}
+
+// Return true if both versions are equal
+func (self GemVersion) Equal(other GemVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self GemVersion) Less(other GemVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version, pre-releases started by - being written with .pre.
+func (self GemVersion) String() string {
+	return strings.ReplaceAll(strings.TrimSpace(self.original), "-", ".pre.")
+}
+
+// Parses comma separated gem requirements, like "~> 2.2, != 2.2.3"
+//
+// Operators are =, !=, <, <=, >=, > and ~>, the pessimistic operator: ~> 2.2
+// matches 2.2 and above below 3, ~> 2.2.0 matches 2.2.0 and above below 2.3.
+// A requirement without operator is an equality. Malformed requirements are
+// reported as a *ConstraintError.
+//
+// Usage
+//     r, _ := version.ParseGemRequirement("~> 2.2, != 2.2.3")
+//     r.Match("2.9.1")
+//     Returns: true
+func ParseGemRequirement(requirement string) (*GemRequirement, error) {
+	group, err := parseRelations(RubyGems, requirement, rubyGemsOperators)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GemRequirement{group.constraints}, nil
+}
+
+// Return true if the version satisfies all the requirements, invalid versions
+// satisfying none
+func (self *GemRequirement) Match(version string) bool {
+	v, err := ParseGem(version)
+	if err != nil {
+		return false
+	}
+
+	for _, requirement := range self.requirements {
+		bound, err := ParseGem(requirement.version)
+		if err != nil {
+			return false
+		}
+
+		if requirement.operator == "~>" {
+			if v.Compare(bound) < 0 || v.Release().Compare(bound.Bump()) >= 0 {
+				return false
+			}
+			continue
+		}
+
+		if !matchOperator(v.Compare(bound), requirement.operator) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return the requirements, like Gem::Requirement#to_s
+func (self *GemRequirement) String() string {
+	requirements := make([]string, len(self.requirements))
+	for i, requirement := range self.requirements {
+		requirements[i] = requirement.operator + " " + requirement.version
+	}
+
+	return strings.Join(requirements, ", ")
+}
+
+// adds one to a string of digits
+func incrementDigits(digits string) string {
+	number := []byte(digits)
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] != '9' {
+			number[i]++
+			return string(number)
+		}
+		number[i] = '0'
+	}
+
+	return "1" + string(number)
+}
+
+type rubyGemsScheme struct{}
+
+func (rubyGemsScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseGem(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (rubyGemsScheme) Compare(version1, version2 string) int {
+	return CompareGem(version1, version2)
+}
+
+func (rubyGemsScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(GemVersion).Compare(v2.(GemVersion))
+}
+
+func (rubyGemsScheme) ParseConstraint(constraint string) (Matcher, error) {
+	requirement, err := ParseGemRequirement(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return requirement, nil
+}
+
+func (rubyGemsScheme) Format(version string) (string, error) {
+	v, err := ParseGem(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
@@ -0,0 +1,142 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var gemValues = map[string]string{
+	"1.0.0":       "1|0|0",
+	"1.0.0.pre.1": "1|0|0|pre|1",
+	"1.0.0-rc1":   "1|0|0|pre|rc|1",
+	"2.0.b3":      "2|0|b|3",
+	"01.002":      "1|2",
+	" 1.2 ":       "1|2",
+	"1.2.3a":      "1|2|3|a",
+}
+
+func TestParseGem(t *testing.T) {
+	for in, out := range gemValues {
+		v, err := ParseGem(in)
+		if x := strings.Join(v.Segments(), "|"); err != nil || x != out || v.Original() != in {
+			t.Errorf("FAIL: ParseGem(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	for _, in := range []string{"a", "1..2", "1.2-", "1.2 3", "-1", "1.2+3", ".1"} {
+		if v, err := ParseGem(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseGem(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// as given by Gem::Version#<=>
+var compareGemValues = map[string]int{
+	"1.0|1.0":             0,
+	"1.0|1":               0,
+	"1.0|1.0.0.0":         0,
+	"1.0|1.1":             -1,
+	"1.10|1.9":            1,
+	"1.0.0.pre.1|1.0.0":   -1,
+	"1.0.0-rc1|1.0.0.rc1": -1,
+	"1.0.0-rc1|1.0.0.pre": -1,
+	"2.0.b3|2.0":          -1,
+	"2.0.b3|2.0.a10":      1,
+	"2.0.b3|2.0.b10":      -1,
+	"1.0.a|1.0.a.0":       0,
+	"1.0.a|1.0.0.a":       0,
+	"1.a|1.0.b":           -1,
+	"1.0.a|1.0.A":         1,
+	"1.8.a|1.8.0.1":       -1,
+	"5.x|5.0.x":           0,
+	"9.0.0|10":            -1,
+	"a|1":                 -1,
+	"a|b":                 0,
+}
+
+func TestCompareGem(t *testing.T) {
+	for in, out := range compareGemValues {
+		v := strings.Split(in, "|")
+		if x := CompareGem(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareGem(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareGem(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareGem(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := RubyGems.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: RubyGems.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var gemReleaseValues = map[string][2]string{
+	"1.0.0.pre.1": {"1.0.0", "1.1"},
+	"2.2.3":       {"2.2.3", "2.3"},
+	"2.2":         {"2.2", "3"},
+	"2":           {"2", "3"},
+	"2.0.b3":      {"2.0", "3"},
+	"1.9":         {"1.9", "2"},
+	"1.99.9":      {"1.99.9", "1.100"},
+}
+
+func TestGemReleaseAndBump(t *testing.T) {
+	for in, out := range gemReleaseValues {
+		v, _ := ParseGem(in)
+		if x := [2]string{v.Release().String(), v.Bump().String()}; x != out {
+			t.Errorf("FAIL: Release, Bump(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var gemRequirements = map[string]bool{
+	"~> 2.2|2.2":             true,
+	"~> 2.2|2.9.1":           true,
+	"~> 2.2|3.0":             false,
+	"~> 2.2|3.0.a":           false,
+	"~> 2.2|2.2.a":           false,
+	"~> 2.2.0|2.2.9":         true,
+	"~> 2.2.0|2.3":           false,
+	"~> 2.2.0|2.3.0.pre":     false,
+	"~>2|2.9":                true,
+	"~> 2.2, != 2.2.3|2.2.3": false,
+	"~> 2.2, != 2.2.3|2.2.4": true,
+	"~> 2.2, >= 2.2.1|2.2.0": false,
+	"1.0|1":                  true,
+	"= 1.0|1.0.1":            false,
+	"> 1.0|1.0.1.a":          true,
+	"< 1.0|1.0.a":            true,
+	">= 1.0.a|1.0.b":         true,
+	"<= 1.0|1.0.0":           true,
+	">= 1.0|2.0.b1":          true,
+	">= 0|invalid":           false,
+}
+
+func TestParseGemRequirement(t *testing.T) {
+	for in, out := range gemRequirements {
+		tmp := strings.Split(in, "|")
+
+		r, err := ParseGemRequirement(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseGemRequirement(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := r.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range map[string]error{"": ErrEmptyConstraint, "== 1.0": ErrInvalidConstraint, "~ 1.0": ErrInvalidConstraint, "~> 1.a.": ErrInvalidVersion} {
+		if r, err := RubyGems.ParseConstraint(in); r != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: RubyGems.ParseConstraint(%v) = %v, %v: want error %v", in, r, err, out)
+		}
+	}
+
+	r, _ := ParseGemRequirement("~>2.2,!= 2.2.3, 1.0")
+	if x := r.String(); x != "~> 2.2, != 2.2.3, = 1.0" {
+		t.Errorf("FAIL: String() = %v: want %v", x, "~> 2.2, != 2.2.3, = 1.0")
+	}
+}
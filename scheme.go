@@ -0,0 +1,170 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scheme holds the rules of a versioning ecosystem, how its versions are
+// parsed, ordered and formatted and how its constraints are written
+//
+// Composer is the default scheme, the one used by the package level functions.
+type Scheme interface {
+	// Parses a version, failing on versions the scheme does not accept
+	Parse(version string) (fmt.Stringer, error)
+	// Compares two versions, returns 0 if both are equal, 1 if version1 is
+	// bigger and -1 if it is lower
+	Compare(version1, version2 string) int
+	// Parses a constraint written in the syntax of the scheme
+	ParseConstraint(constraint string) (Matcher, error)
+	// Return the canonical form of a version
+	Format(version string) (string, error)
+}
+
+// Matcher tells if a version satisfies a constraint
+type Matcher interface {
+	Match(version string) bool
+}
+
+// Composer is the scheme of Composer and PHP version_compare, the one used by
+// Normalize, Compare and NewConstrainGroupFromString
+var Composer Scheme = composerScheme{}
+
+var schemes = struct {
+	sync.RWMutex
+	registry map[string]Scheme
+}{registry: make(map[string]Scheme)}
+
+func init() {
+	RegisterScheme("composer", Composer)
+}
+
+// Registers a scheme under a name, to be found with LookupScheme
+//
+// Panics if the scheme is nil or the name is already taken.
+func RegisterScheme(name string, scheme Scheme) {
+	schemes.Lock()
+	defer schemes.Unlock()
+
+	if scheme == nil {
+		panic("version: RegisterScheme scheme is nil")
+	}
+
+	if _, dup := schemes.registry[name]; dup {
+		panic("version: RegisterScheme called twice for scheme " + name)
+	}
+
+	schemes.registry[name] = scheme
+}
+
+// Return the scheme registered under name
+//
+// Usage
+//     scheme, _ := version.LookupScheme("composer")
+//     scheme.Compare("1.0rc1", "1.0")
+//     Returns: -1
+func LookupScheme(name string) (Scheme, bool) {
+	schemes.RLock()
+	defer schemes.RUnlock()
+
+	scheme, ok := schemes.registry[name]
+
+	return scheme, ok
+}
+
+// Return the sorted names of the registered schemes
+func Schemes() []string {
+	schemes.RLock()
+	defer schemes.RUnlock()
+
+	names := make([]string, 0, len(schemes.registry))
+	for name := range schemes.registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Compares two version strings of a scheme, for a particular relationship
+//
+// Same operators than Compare.
+//
+// Usage
+//     version.CompareWith(version.Composer, "2.3.4", "v3.1.2", "<")
+//     Returns: true
+func CompareWith(scheme Scheme, version1, version2, operator string) bool {
+	return matchOperator(scheme.Compare(version1, version2), operator)
+}
+
+// Parses comma separated relations, like ">= 1.0, << 2.0", into a group
+// matched with scheme
+//
+// operators maps the operators accepted by the scheme to the ones of Compare,
+// a relation without operator is looked up as "". Each relation can be
+// wrapped in parentheses, as in Debian control files.
+func parseRelations(scheme Scheme, constraint string, operators map[string]string) (*ConstraintGroup, error) {
+	group := &ConstraintGroup{scheme: scheme}
+
+	start := 0
+	for _, relation := range strings.Split(constraint, ",") {
+		offset := start + len(relation) - len(strings.TrimLeft(relation, " \t("))
+		start += len(relation) + 1
+
+		relation = strings.Trim(relation, " \t()")
+		if relation == "" {
+			return nil, &ConstraintError{constraint, relation, offset, ErrEmptyConstraint}
+		}
+
+		split := strings.IndexFunc(relation, func(char rune) bool {
+			return !strings.ContainsRune("<>=!~", char)
+		})
+		if split < 0 {
+			split = len(relation)
+		}
+
+		operator, ok := operators[relation[:split]]
+		if !ok {
+			return nil, &ConstraintError{constraint, relation, offset, ErrInvalidConstraint}
+		}
+
+		version := strings.TrimLeft(relation[split:], " \t")
+		if _, err := scheme.Parse(version); err != nil {
+			return nil, &ConstraintError{constraint, relation, offset, err}
+		}
+
+		group.AddConstraint(NewConstrain(operator, version))
+	}
+
+	return group, nil
+}
+
+type composerScheme struct{}
+
+func (composerScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseStrict(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (composerScheme) Compare(version1, version2 string) int {
+	return CompareSimple(Normalize(version1), Normalize(version2))
+}
+
+func (composerScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseConstraintGroup(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (composerScheme) Format(version string) (string, error) {
+	return NormalizeStrict(version)
+}
@@ -0,0 +1,98 @@
+package version
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLookupScheme(t *testing.T) {
+	scheme, ok := LookupScheme("composer")
+	if !ok || scheme != Composer {
+		t.Errorf("FAIL: LookupScheme(composer) = %v, %v: want %v, true", scheme, ok, Composer)
+	}
+
+	if scheme, ok := LookupScheme("banana"); ok || scheme != nil {
+		t.Errorf("FAIL: LookupScheme(banana) = %v, %v: want nil, false", scheme, ok)
+	}
+}
+
+func TestRegisterScheme(t *testing.T) {
+	RegisterScheme("test-composer", composerScheme{})
+	defer func() {
+		schemes.Lock()
+		delete(schemes.registry, "test-composer")
+		schemes.Unlock()
+	}()
+
+	names := Schemes()
+	if !sort.StringsAreSorted(names) || sort.SearchStrings(names, "test-composer") == len(names) {
+		t.Errorf("FAIL: Schemes() = %v: want sorted names with test-composer", names)
+	}
+
+	for _, scheme := range []Scheme{composerScheme{}, nil} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FAIL: RegisterScheme(test-composer, %v) did not panic", scheme)
+				}
+			}()
+
+			RegisterScheme("test-composer", scheme)
+		}()
+	}
+}
+
+func TestComposerCompare(t *testing.T) {
+	for in, out := range compareVersionSimpleValues {
+		v := strings.Split(in, "|")
+		if x := Composer.Compare(v[0], v[1]); x != CompareSimple(Normalize(v[0]), Normalize(v[1])) {
+			t.Errorf("FAIL: Composer.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range compareVersionValues {
+		v := strings.Split(in, " ")
+		if x := CompareWith(Composer, v[0], v[2], v[1]); x != out {
+			t.Errorf("FAIL: CompareWith(Composer, %v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+func TestComposerParse(t *testing.T) {
+	for in, out := range versions {
+		v, err := Composer.Parse(in)
+		if err != nil || v.String() != out {
+			t.Errorf("FAIL: Composer.Parse(%v) = %v, %v: want %v", in, v, err, out)
+		}
+
+		if x, err := Composer.Format(in); x != out || err != nil {
+			t.Errorf("FAIL: Composer.Format(%v) = %v, %v: want %v", in, x, err, out)
+		}
+	}
+
+	if v, err := Composer.Parse("1.0-foo"); v != nil || !errors.Is(err, ErrUnknownModifier) {
+		t.Errorf("FAIL: Composer.Parse(1.0-foo) = %v, %v: want nil, %v", v, err, ErrUnknownModifier)
+	}
+}
+
+func TestComposerParseConstraint(t *testing.T) {
+	for in, out := range miscConstraints {
+		tmp := strings.Split(in, "|")
+
+		matcher, err := Composer.ParseConstraint(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: Composer.ParseConstraint(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := matcher.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	if matcher, err := Composer.ParseConstraint(">=1.0, <2.0-foo"); matcher != nil || err == nil {
+		t.Errorf("FAIL: Composer.ParseConstraint(>=1.0, <2.0-foo) = %v, %v: want nil, error", matcher, err)
+	}
+}
@@ -0,0 +1,288 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SemVer is a version following Semantic Versioning 2.0.0
+//
+// Unlike Version it follows the precedence rules of https://semver.org rather
+// than PHP version_compare: pre-release identifiers are compared one by one,
+// numerically or in ASCII order, and build metadata is ignored.
+type SemVer struct {
+	original   string
+	major      uint64
+	minor      uint64
+	patch      uint64
+	prerelease []string
+	build      []string
+}
+
+// Parses a strict Semantic Versioning 2.0.0 version
+//
+// No v prefix, missing segments or leading zeros are allowed, invalid
+// versions are reported as a *ParseError wrapping ErrInvalidSemVer.
+//
+// Usage
+//     v, _ := version.ParseSemVer("1.0.0-alpha.1+001")
+//     v.Prerelease()
+//     Returns: []string{"alpha", "1"}
+func ParseSemVer(version string) (SemVer, error) {
+	if version == "" {
+		return SemVer{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	v := SemVer{original: version}
+
+	rest := version
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build, err := splitIdentifiers(version, rest[i+1:], false)
+		if err != nil {
+			return SemVer{}, err
+		}
+		v.build = build
+		rest = rest[:i]
+	}
+
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		prerelease, err := splitIdentifiers(version, rest[i+1:], true)
+		if err != nil {
+			return SemVer{}, err
+		}
+		v.prerelease = prerelease
+		rest = rest[:i]
+	}
+
+	segments := strings.Split(rest, ".")
+	if len(segments) != 3 {
+		return SemVer{}, &ParseError{version, ErrInvalidSemVer, "expected major.minor.patch"}
+	}
+
+	numbers := [3]*uint64{&v.major, &v.minor, &v.patch}
+	for i, segment := range segments {
+		if !isNumericIdentifier(segment) {
+			return SemVer{}, &ParseError{version, ErrInvalidSemVer, "invalid number " + strconv.Quote(segment)}
+		}
+
+		number, err := strconv.ParseUint(segment, 10, 64)
+		if err != nil {
+			return SemVer{}, &ParseError{version, ErrInvalidSemVer, "number out of range " + strconv.Quote(segment)}
+		}
+		*numbers[i] = number
+	}
+
+	return v, nil
+}
+
+// Return true if version is a valid Semantic Versioning 2.0.0 version
+func IsSemVer(version string) bool {
+	_, err := ParseSemVer(version)
+
+	return err == nil
+}
+
+// Compares two Semantic Versioning 2.0.0 version strings
+//
+// Returns 0 if both versions have the same precedence, 1 if version1 is bigger
+// and -1 if it is lower. Invalid versions are lower than any valid one and
+// equal to each other.
+//
+// Usage
+//     version.CompareSemVer("1.0.0-alpha.beta", "1.0.0-alpha.1")
+//     Returns: 1
+//
+//     version.CompareSemVer("1.0.0+20130313", "1.0.0")
+//     Returns: 0
+func CompareSemVer(version1, version2 string) int {
+	v1, err1 := ParseSemVer(version1)
+	v2, err2 := ParseSemVer(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Compares two parsed versions given their parse errors, invalid versions
+// being lower than any valid one and equal to each other, compare being
+// called only when both are valid
+func compareParsed(err1, err2 error, compare func() int) int {
+	switch {
+	case err1 != nil && err2 != nil:
+		return 0
+	case err1 != nil:
+		return -1
+	case err2 != nil:
+		return 1
+	}
+
+	return compare()
+}
+
+// splits pre-release or build identifiers, pre-release numeric identifiers
+// can not have leading zeros
+func splitIdentifiers(version, identifiers string, prerelease bool) ([]string, error) {
+	parts := strings.Split(identifiers, ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, &ParseError{version, ErrInvalidSemVer, "empty identifier"}
+		}
+
+		for i := 0; i < len(part); i++ {
+			char := part[i]
+			if !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char == '-') {
+				return nil, &ParseError{version, ErrInvalidSemVer, "invalid identifier " + strconv.Quote(part)}
+			}
+		}
+
+		if prerelease && isDigits(part) && !isNumericIdentifier(part) {
+			return nil, &ParseError{version, ErrInvalidSemVer, "leading zero in " + strconv.Quote(part)}
+		}
+	}
+
+	return parts, nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// digits without leading zeros
+func isNumericIdentifier(value string) bool {
+	return isDigits(value) && (value == "0" || value[0] != '0')
+}
+
+// Return the major version number
+func (self SemVer) Major() uint64 {
+	return self.major
+}
+
+// Return the minor version number
+func (self SemVer) Minor() uint64 {
+	return self.minor
+}
+
+// Return the patch version number
+func (self SemVer) Patch() uint64 {
+	return self.patch
+}
+
+// Return the dot separated pre-release identifiers, nil for releases
+func (self SemVer) Prerelease() []string {
+	return append([]string(nil), self.prerelease...)
+}
+
+// Return the dot separated build metadata identifiers
+func (self SemVer) Build() []string {
+	return append([]string(nil), self.build...)
+}
+
+// Return the string given to ParseSemVer
+func (self SemVer) Original() string {
+	return self.original
+}
+
+// Compares precedence against other version
+//
+// Returns 0 if both are equal, build metadata being ignored, 1 if self is
+// bigger and -1 if self is lower.
+func (self SemVer) Compare(other SemVer) int {
+	if cmp := compareUint(self.major, other.major); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareUint(self.minor, other.minor); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareUint(self.patch, other.patch); cmp != 0 {
+		return cmp
+	}
+
+	return comparePrerelease(self.prerelease, other.prerelease)
+}
+
+// the version without pre-release and build metadata
+func (self SemVer) release() string {
+	return strconv.FormatUint(self.major, 10) + "." + strconv.FormatUint(self.minor, 10) + "." +
+		strconv.FormatUint(self.patch, 10)
+}
+
+// Return true if both versions have the same precedence
+func (self SemVer) Equal(other SemVer) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self has lower precedence than other
+func (self SemVer) Less(other SemVer) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version in its canonical form
+func (self SemVer) String() string {
+	version := strconv.FormatUint(self.major, 10) + "." +
+		strconv.FormatUint(self.minor, 10) + "." +
+		strconv.FormatUint(self.patch, 10)
+
+	if len(self.prerelease) > 0 {
+		version = version + "-" + strings.Join(self.prerelease, ".")
+	}
+
+	if len(self.build) > 0 {
+		version = version + "+" + strings.Join(self.build, ".")
+	}
+
+	return version
+}
+
+func compareUint(a, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+
+	return 0
+}
+
+// a version without pre-release has higher precedence, otherwise identifiers
+// are compared from left to right, the longer list winning on equal prefixes
+func comparePrerelease(v1, v2 []string) int {
+	if len(v1) == 0 || len(v2) == 0 {
+		return compareUint(uint64(len(v2)), uint64(len(v1)))
+	}
+
+	for i := 0; i < len(v1) && i < len(v2); i++ {
+		if cmp := compareIdentifier(v1[i], v2[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareUint(uint64(len(v1)), uint64(len(v2)))
+}
+
+// numeric identifiers are compared numerically and have lower precedence
+// than alphanumeric ones, compared in ASCII order
+func compareIdentifier(a, b string) int {
+	numericA, numericB := isDigits(a), isDigits(b)
+
+	switch {
+	case numericA && numericB:
+		if len(a) != len(b) {
+			return compareUint(uint64(len(a)), uint64(len(b)))
+		}
+		return strings.Compare(a, b)
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
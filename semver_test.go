@@ -0,0 +1,126 @@
+package version
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var semVerValues = map[string]string{
+	"0.0.0":                          "0.0.0",
+	"1.2.3":                          "1.2.3",
+	"1.0.0-alpha":                    "1.0.0-alpha",
+	"1.0.0-alpha.1":                  "1.0.0-alpha.1",
+	"1.0.0-0.3.7":                    "1.0.0-0.3.7",
+	"1.0.0-x.7.z.92":                 "1.0.0-x.7.z.92",
+	"1.0.0-x-y-z.--":                 "1.0.0-x-y-z.--",
+	"1.0.0-alpha+001":                "1.0.0-alpha+001",
+	"1.0.0+20130313144700":           "1.0.0+20130313144700",
+	"1.0.0-beta+exp.sha.5114f85":     "1.0.0-beta+exp.sha.5114f85",
+	"1.0.0+21AF26D3----117B344092BD": "1.0.0+21AF26D3----117B344092BD",
+}
+
+func TestParseSemVer(t *testing.T) {
+	for in, out := range semVerValues {
+		v, err := ParseSemVer(in)
+		if err != nil || v.String() != out || v.Original() != in {
+			t.Errorf("FAIL: ParseSemVer(%v) = %v, %v: want %v", in, v, err, out)
+		}
+
+		if !IsSemVer(in) {
+			t.Errorf("FAIL: IsSemVer(%v) = false: want true", in)
+		}
+	}
+
+	v, _ := ParseSemVer("1.2.3-alpha.1+build.5")
+	if v.Major() != 1 || v.Minor() != 2 || v.Patch() != 3 ||
+		!reflect.DeepEqual(v.Prerelease(), []string{"alpha", "1"}) ||
+		!reflect.DeepEqual(v.Build(), []string{"build", "5"}) {
+		t.Errorf("FAIL: ParseSemVer(1.2.3-alpha.1+build.5) = %+v", v)
+	}
+}
+
+var invalidSemVerValues = []string{
+	"1", "1.2", "1.2.3.4", "v1.2.3", "01.2.3", "1.02.3", "1.2.03", "1.2.3-",
+	"1.2.3-01", "1.2.3-alpha..1", "1.2.3+", "1.2.3+a..b", "1.2.3-alpha_1",
+	"1.2.3+ü", "1.2.-3", "a.b.c", "99999999999999999999.0.0",
+}
+
+func TestParseSemVerInvalid(t *testing.T) {
+	for _, in := range invalidSemVerValues {
+		if v, err := ParseSemVer(in); !errors.Is(err, ErrInvalidSemVer) {
+			t.Errorf("FAIL: ParseSemVer(%v) = %v, %v: want error %v", in, v, err, ErrInvalidSemVer)
+		}
+
+		if IsSemVer(in) {
+			t.Errorf("FAIL: IsSemVer(%v) = true: want false", in)
+		}
+	}
+
+	if _, err := ParseSemVer(""); !errors.Is(err, ErrEmptyVersion) {
+		t.Errorf("FAIL: ParseSemVer() error = %v: want %v", err, ErrEmptyVersion)
+	}
+}
+
+// ordered by precedence, as listed by the specification
+var semVerPrecedence = []string{
+	"1.0.0-alpha",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha.beta",
+	"1.0.0-beta",
+	"1.0.0-beta.2",
+	"1.0.0-beta.11",
+	"1.0.0-rc.1",
+	"1.0.0",
+	"1.0.1-0",
+	"1.0.1-x.7.z.92",
+	"1.0.1",
+	"1.9.0",
+	"1.10.0",
+	"2.0.0",
+	"2.1.0",
+	"2.1.1",
+}
+
+func TestCompareSemVerPrecedence(t *testing.T) {
+	for i, lower := range semVerPrecedence {
+		for _, higher := range semVerPrecedence[i+1:] {
+			if x := CompareSemVer(lower, higher); x != -1 {
+				t.Errorf("FAIL: CompareSemVer(%v, %v) = %v: want -1", lower, higher, x)
+			}
+
+			if x := CompareSemVer(higher, lower); x != 1 {
+				t.Errorf("FAIL: CompareSemVer(%v, %v) = %v: want 1", higher, lower, x)
+			}
+		}
+	}
+}
+
+var compareSemVerValues = map[string]int{
+	"1.0.0|1.0.0":                            0,
+	"1.0.0+20130313|1.0.0":                   0,
+	"1.0.0-beta+a|1.0.0-beta+b":              0,
+	"1.0.0-alpha.10|1.0.0-alpha.9":           1,
+	"1.0.0-alpha.10|1.0.0-alpha.9a":          -1,
+	"1.0.0-Beta|1.0.0-alpha":                 -1,
+	"v1.0.0|1.0.0":                           -1,
+	"1.0.0|invalid":                          1,
+	"invalid|also.invalid":                   0,
+	"1.0.0-1111111111111111111111|1.0.0-999": 1,
+}
+
+func TestCompareSemVer(t *testing.T) {
+	for in, out := range compareSemVerValues {
+		v := strings.Split(in, "|")
+		if x := CompareSemVer(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareSemVer(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	v1, _ := ParseSemVer("1.0.0-alpha")
+	v2, _ := ParseSemVer("1.0.0")
+	if !v1.Less(v2) || v1.Equal(v2) || !v2.Equal(v2) {
+		t.Errorf("FAIL: Less/Equal(%v, %v)", v1, v2)
+	}
+}
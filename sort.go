@@ -1,36 +1,243 @@
 package version
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 )
 
 // Sorts a string slice of version number strings using version.CompareSimple()
 //
+// Each version is normalized and split only once, equal versions are ordered
+// by their raw string.
+//
 // Example:
 //     version.Sort([]string{"1.10-dev", "1.0rc1", "1.0", "1.0-dev"})
 //     Returns []string{"1.0-dev", "1.0rc1", "1.0", "1.10-dev"}
 //
 func Sort(versionStrings []string) {
-	versions := versionSlice(versionStrings)
+	versions := newVersionSlice(versionStrings, false, true)
+	sort.Sort(versions)
+	versions.store(versionStrings)
+}
+
+// Sorts a string slice of version number strings from the highest to the
+// lowest one, the reverse order of Sort
+//
+// Example:
+//     version.SortDesc([]string{"1.10-dev", "1.0rc1", "1.0", "1.0-dev"})
+//     Returns []string{"1.10-dev", "1.0", "1.0rc1", "1.0-dev"}
+//
+func SortDesc(versionStrings []string) {
+	versions := newVersionSlice(versionStrings, true, true)
+	sort.Sort(versions)
+	versions.store(versionStrings)
+}
+
+// Sorts a string slice of version number strings keeping equal versions, like
+// 1.0 and 1.0.0, in their original order
+//
+// Example:
+//     version.SortStable([]string{"1.0.0", "0.9", "1.0"})
+//     Returns []string{"0.9", "1.0.0", "1.0"}
+//
+func SortStable(versionStrings []string) {
+	versions := newVersionSlice(versionStrings, false, false)
+	sort.Stable(versions)
+	versions.store(versionStrings)
+}
+
+// Sorts a string slice of version number strings using the ordering of a
+// scheme, equal versions being ordered by their raw string
+//
+// Versions are parsed only once for the schemes of this package.
+//
+// Example:
+//     version.SortWith(version.RPM, []string{"1.0", "1.0^post1", "1.0~rc1"})
+//     Returns []string{"1.0~rc1", "1.0", "1.0^post1"}
+//
+func SortWith(scheme Scheme, versionStrings []string) {
+	if scheme == Composer {
+		Sort(versionStrings)
+		return
+	}
+
+	comparer, ok := scheme.(valueComparer)
+	if !ok {
+		sort.Sort(&schemeSlice{scheme, versionStrings})
+		return
+	}
+
+	versions := newParsedSlice(scheme, comparer, versionStrings)
 	sort.Sort(versions)
+	versions.store(versionStrings)
 }
 
-type versionSlice []string
+// Sorts a slice of any type by the version string key returns for each item,
+// with the same order than Sort
+//
+// key is called once per item.
+//
+// Example:
+//     version.SortBy(releases, func(r Release) string { return r.Version })
+//
+func SortBy[T any](items []T, key func(T) string) {
+	raws := make([]string, len(items))
+	for i, item := range items {
+		raws[i] = key(item)
+	}
 
-func (s versionSlice) Len() int {
-	return len(s)
+	sort.Sort(&itemSlice[T]{items, newVersionSlice(raws, false, true)})
 }
 
-func (s versionSlice) Less(i, j int) bool {
-	cmp := CompareSimple(Normalize(s[i]), Normalize(s[j]))
+// Compares two version strings, returning a negative number when version1 is
+// lower, a positive one when it is bigger and 0 when both strings are equal
+//
+// Same order than Sort, versions like 1.0 and 1.0.0 being ordered by their raw
+// string, so it can be given to slices.SortFunc.
+//
+// Example:
+//     slices.SortFunc(tags, version.CompareFunc)
+//
+func CompareFunc(version1, version2 string) int {
+	if cmp := CompareSimple(Normalize(version1), Normalize(version2)); cmp != 0 {
+		return cmp
+	}
+
+	return strings.Compare(version1, version2)
+}
+
+type schemeSlice struct {
+	scheme   Scheme
+	versions []string
+}
+
+func (s *schemeSlice) Len() int {
+	return len(s.versions)
+}
+
+func (s *schemeSlice) Less(i, j int) bool {
+	cmp := s.scheme.Compare(s.versions[i], s.versions[j])
 	if cmp == 0 {
-		return s[i] < s[j]
+		return s.versions[i] < s.versions[j]
+	}
+	return cmp < 0
+}
+
+func (s *schemeSlice) Swap(i, j int) {
+	s.versions[i], s.versions[j] = s.versions[j], s.versions[i]
+}
+
+// schemes able to compare the versions returned by their Parse, so SortWith
+// parses each version once
+type valueComparer interface {
+	compareValues(v1, v2 fmt.Stringer) int
+}
+
+type parsedKey struct {
+	raw     string
+	version fmt.Stringer
+	err     error
+}
+
+type parsedSlice struct {
+	keys     []parsedKey
+	comparer valueComparer
+}
+
+func newParsedSlice(scheme Scheme, comparer valueComparer, versionStrings []string) *parsedSlice {
+	keys := make([]parsedKey, len(versionStrings))
+	for i, raw := range versionStrings {
+		version, err := scheme.Parse(raw)
+		keys[i] = parsedKey{raw, version, err}
+	}
+
+	return &parsedSlice{keys, comparer}
+}
+
+func (s *parsedSlice) store(versionStrings []string) {
+	for i, key := range s.keys {
+		versionStrings[i] = key.raw
+	}
+}
+
+func (s *parsedSlice) Len() int {
+	return len(s.keys)
+}
+
+func (s *parsedSlice) Less(i, j int) bool {
+	a, b := s.keys[i], s.keys[j]
+	cmp := compareParsed(a.err, b.err, func() int { return s.comparer.compareValues(a.version, b.version) })
+	if cmp == 0 {
+		return a.raw < b.raw
+	}
+	return cmp < 0
+}
+
+func (s *parsedSlice) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+type itemSlice[T any] struct {
+	items    []T
+	versions *versionSlice
+}
+
+func (s *itemSlice[T]) Len() int {
+	return len(s.items)
+}
+
+func (s *itemSlice[T]) Less(i, j int) bool {
+	return s.versions.Less(i, j)
+}
+
+func (s *itemSlice[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.versions.Swap(i, j)
+}
+
+type versionKey struct {
+	raw     string
+	numbers []int
+}
+
+type versionSlice struct {
+	keys     []versionKey
+	desc     bool
+	tiebreak bool
+}
+
+func newVersionSlice(versionStrings []string, desc, tiebreak bool) *versionSlice {
+	keys := make([]versionKey, len(versionStrings))
+	for i, raw := range versionStrings {
+		keys[i] = versionKey{raw, prepNumbers(Normalize(raw))}
+	}
+
+	return &versionSlice{keys, desc, tiebreak}
+}
+
+func (s *versionSlice) store(versionStrings []string) {
+	for i, key := range s.keys {
+		versionStrings[i] = key.raw
+	}
+}
+
+func (s *versionSlice) Len() int {
+	return len(s.keys)
+}
+
+func (s *versionSlice) Less(i, j int) bool {
+	if s.desc {
+		i, j = j, i
+	}
+
+	cmp := compareNumbers(s.keys[i].numbers, s.keys[j].numbers)
+	if cmp == 0 && s.tiebreak {
+		return s.keys[i].raw < s.keys[j].raw
 	}
 	return cmp < 0
 }
 
-func (s versionSlice) Swap(i, j int) {
-	tmp := s[j]
-	s[j] = s[i]
-	s[i] = tmp
+func (s *versionSlice) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
 }
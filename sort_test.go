@@ -1,7 +1,10 @@
 package version
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -79,3 +82,144 @@ func TestSort(t *testing.T) {
 		}
 	}
 }
+
+func TestSortDesc(t *testing.T) {
+	input := []string{"1.10-dev", "1.0rc1", "1.0", "1.0-dev", "1.0.0"}
+	output := []string{"1.10-dev", "1.0.0", "1.0", "1.0rc1", "1.0-dev"}
+
+	SortDesc(input)
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("Expected output %+v did not match actual %+v", output, input)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	input := []string{"1.0.0", "v1.0", "0.9", "1.0", "1.0-dev"}
+	output := []string{"0.9", "1.0-dev", "1.0.0", "v1.0", "1.0"}
+
+	SortStable(input)
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("Expected output %+v did not match actual %+v", output, input)
+	}
+}
+
+func TestSortMatchesCompareSimple(t *testing.T) {
+	input := benchmarkVersions(2000)
+	Sort(input)
+
+	for i := 1; i < len(input); i++ {
+		cmp := CompareSimple(Normalize(input[i-1]), Normalize(input[i]))
+		if cmp > 0 || (cmp == 0 && input[i-1] > input[i]) {
+			t.Errorf("FAIL: %v sorted before %v", input[i-1], input[i])
+		}
+	}
+}
+
+type release struct {
+	name    string
+	version string
+}
+
+func TestSortBy(t *testing.T) {
+	input := []release{{"d", "1.10-dev"}, {"c", "1.0rc1"}, {"b", "1.0"}, {"a", "1.0-dev"}}
+	output := []release{{"a", "1.0-dev"}, {"c", "1.0rc1"}, {"b", "1.0"}, {"d", "1.10-dev"}}
+
+	SortBy(input, func(r release) string { return r.version })
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("Expected output %+v did not match actual %+v", output, input)
+	}
+}
+
+var compareFuncValues = map[string]int{
+	"1.0|1.1":         -1,
+	"1.0rc1|1.0":      -1,
+	"1.10|1.9":        1,
+	"1.0|1.0":         0,
+	"1.0.0|1.0":       1,
+	"v1.0|1.0":        1,
+	"1.0-dev|1.0-dev": 0,
+}
+
+func TestCompareFunc(t *testing.T) {
+	for in, out := range compareFuncValues {
+		v := strings.Split(in, "|")
+		if x := CompareFunc(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareFunc(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for _, testcase := range [][]string{benchmarkVersions(500), {"1.0.0", "v1.0", "1.0", "0.9"}} {
+		expected := append([]string(nil), testcase...)
+		Sort(expected)
+
+		sort.Slice(testcase, func(i, j int) bool { return CompareFunc(testcase[i], testcase[j]) < 0 })
+		if !reflect.DeepEqual(testcase, expected) {
+			t.Errorf("Expected output %+v did not match actual %+v", expected, testcase)
+		}
+	}
+}
+
+// deterministic list of tags as found in artifact registries
+func benchmarkVersions(count int) []string {
+	modifiers := []string{"", "-beta", "-RC2", "-dev", "alpha1", "-p1"}
+
+	versions := make([]string, count)
+	for i := range versions {
+		versions[i] = fmt.Sprintf("v%d.%d.%d%s", i%7, (i*31)%23, (i*17)%101, modifiers[i%len(modifiers)])
+	}
+
+	return versions
+}
+
+func BenchmarkSort(b *testing.B) {
+	versions := benchmarkVersions(50000)
+	input := make([]string, len(versions))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(input, versions)
+		Sort(input)
+	}
+}
+
+func BenchmarkSortStable(b *testing.B) {
+	versions := benchmarkVersions(50000)
+	input := make([]string, len(versions))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(input, versions)
+		SortStable(input)
+	}
+}
+
+func TestSortWith(t *testing.T) {
+	input := []string{"1.0", "1.0^post1", "1:0.9", "1.0~rc1", "1.0-2", "0:1.0"}
+	output := []string{"1.0~rc1", "0:1.0", "1.0", "1.0-2", "1.0^post1", "1:0.9"}
+
+	SortWith(RPM, input)
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("Expected output %+v did not match actual %+v", output, input)
+	}
+}
+
+func TestSortWithInvalid(t *testing.T) {
+	input := []string{"2.0-1", "b", "1.0~rc1", "a"}
+	output := []string{"a", "b", "1.0~rc1", "2.0-1"}
+
+	SortWith(Debian, input)
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("Expected output %+v did not match actual %+v", output, input)
+	}
+}
+
+func BenchmarkSortWith(b *testing.B) {
+	versions := benchmarkVersions(50000)
+	input := make([]string, len(versions))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(input, versions)
+		SortWith(RPM, input)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+var regexpReference = regexp.MustCompile(`(?i)#.+$`)
+var regexpStability = regexp.MustCompile(`(?i)(stable|RC|beta|alpha|dev)`)
+
 const (
 	Development = iota
 	Alpha
@@ -33,14 +36,14 @@ func expandStability(stability string) string {
 }
 
 func parseStability(version string) string {
-	version = regexp.MustCompile(`(?i)#.+$`).ReplaceAllString(version, " ")
+	version = regexpReference.ReplaceAllString(version, " ")
 	version = strings.ToLower(version)
 
 	if strings.HasPrefix(version, "dev-") || strings.HasSuffix(version, "-dev") {
 		return "dev"
 	}
 
-	result := RegFind(`(?i)^v?(\d{1,3})(\.\d+)?(\.\d+)?(\.\d+)?`+modifierRegex+`$`, version)
+	result := regexpClassical.FindStringSubmatch(version)
 	if result != nil {
 		if len(result) > 3 {
 			return "dev"
@@ -63,7 +66,9 @@ func parseStability(version string) string {
 }
 
 func GetStability(version string) int {
-	result := RegFind(`(?i)(stable|RC|beta|alpha|dev)`, Normalize(version))
+	release, _ := splitBuild(Normalize(version))
+
+	result := regexpStability.FindStringSubmatch(release)
 	if len(result) == 0 {
 		return Stable
 	}
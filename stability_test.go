@@ -10,6 +10,7 @@ var stabilityValues = map[string]int{
 	"1.0-alpha": Alpha,
 	"1.0b1":     Beta,
 	"1.0rc1":    RC,
+	"1.0+beta":  Stable,
 }
 
 func TestGetStability(t *testing.T) {
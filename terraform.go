@@ -0,0 +1,371 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TerraformVersion is a version as understood by Terraform and the other
+// HashiCorp tools, like 1.5.0, v1.6.0-beta1 or 1.2
+//
+// Any number of numbers is accepted, missing ones being zeros, followed by
+// an optional pre-release and build metadata ordered like Semantic
+// Versioning.
+type TerraformVersion struct {
+	original   string
+	segments   []uint64
+	prerelease []string
+	build      []string
+}
+
+// Terraform is the scheme of Terraform and HashiCorp tools, registered as
+// "terraform"
+//
+// Constraints are comma separated comparisons, including the pessimistic
+// operator ~>, like ~> 1.5.0, != 1.5.3.
+var Terraform Scheme = terraformScheme{}
+
+var terraformOperators = map[string]string{
+	"":   "=",
+	"=":  "=",
+	"!=": "!=",
+	"<":  "<",
+	"<=": "<=",
+	">=": ">=",
+	">":  ">",
+	"~>": "~>",
+}
+
+func init() {
+	RegisterScheme("terraform", Terraform)
+}
+
+// Parses a Terraform version
+//
+// Invalid versions are reported as a *ParseError wrapping ErrInvalidVersion.
+//
+// Usage
+//     v, _ := version.ParseTerraformVersion("v1.6-beta1")
+//     v.String()
+//     Returns: "1.6.0-beta1"
+func ParseTerraformVersion(version string) (TerraformVersion, error) {
+	rest := strings.TrimSpace(version)
+	if rest == "" {
+		return TerraformVersion{}, &ParseError{version, ErrEmptyVersion, ""}
+	}
+
+	v := TerraformVersion{original: version}
+	rest = strings.TrimPrefix(rest, "v")
+
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build, err := splitIdentifiers(version, rest[i+1:], false)
+		if err != nil {
+			return TerraformVersion{}, &ParseError{version, ErrInvalidVersion, err.(*ParseError).Detail}
+		}
+		v.build = build
+		rest = rest[:i]
+	}
+
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		prerelease, err := splitIdentifiers(version, rest[i+1:], false)
+		if err != nil {
+			return TerraformVersion{}, &ParseError{version, ErrInvalidVersion, err.(*ParseError).Detail}
+		}
+		v.prerelease = prerelease
+		rest = rest[:i]
+	}
+
+	for _, segment := range strings.Split(rest, ".") {
+		number, err := strconv.ParseUint(segment, 10, 64)
+		if err != nil || !isDigits(segment) {
+			return TerraformVersion{}, &ParseError{version, ErrInvalidVersion, "invalid number " + strconv.Quote(segment)}
+		}
+		v.segments = append(v.segments, number)
+	}
+
+	return v, nil
+}
+
+// Compares two Terraform versions, like hashicorp/go-version
+//
+// Returns 0 if both versions are equal, 1 if version1 is bigger and -1 if it
+// is lower. Invalid versions are lower than any valid one and equal to each
+// other.
+//
+// Usage
+//     version.CompareTerraform("1.5", "1.5.0.0")
+//     Returns: 0
+//
+//     version.CompareTerraform("1.6.0-beta1", "1.6.0")
+//     Returns: -1
+func CompareTerraform(version1, version2 string) int {
+	v1, err1 := ParseTerraformVersion(version1)
+	v2, err2 := ParseTerraformVersion(version2)
+
+	return compareParsed(err1, err2, func() int { return v1.Compare(v2) })
+}
+
+// Parses a Terraform version constraint, like "~> 1.5.0, != 1.5.3"
+//
+// All the comma separated comparisons have to match. The pessimistic
+// operator ~> only lets the right-most given number increment: ~> 1.5.0
+// means >= 1.5.0, < 1.6.0 and ~> 1.5 means >= 1.5, < 2.0. Like Terraform, a
+// pre-release only matches comparisons naming a pre-release of the same
+// numbers, or = and != ones.
+//
+// The constraint is returned as a ConstraintGroup of plain comparisons.
+// Malformed constraints are reported as a *ConstraintError.
+//
+// Usage
+//     c, _ := version.ParseTerraformConstraint("~> 1.5.0, != 1.5.3")
+//     c.Match("1.5.7")
+//     Returns: true
+//
+//     c.Match("1.6.0")
+//     Returns: false
+func ParseTerraformConstraint(constraint string) (*ConstraintGroup, error) {
+	relations, err := parseRelations(Terraform, constraint, terraformOperators)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &ConstraintGroup{
+		scheme:   Terraform,
+		admit:    admitTerraform(relations.constraints),
+		admitted: admittedTerraform(relations.constraints),
+	}
+	for _, relation := range relations.constraints {
+		if relation.operator != "~>" {
+			group.AddConstraint(relation)
+			continue
+		}
+
+		group.AddConstraint(&Constraint{">=", relation.version})
+
+		v, _ := ParseTerraformVersion(relation.version)
+		if bound := v.pessimisticBound(); bound != "" {
+			group.AddConstraint(&Constraint{"<", bound})
+		}
+	}
+
+	return group, nil
+}
+
+// Return the string given to ParseTerraformVersion
+func (self TerraformVersion) Original() string {
+	return self.original
+}
+
+// Return the numbers of the version, at least 3 of them
+func (self TerraformVersion) Segments() []uint64 {
+	segments := make([]uint64, 3)
+	copy(segments, self.segments)
+	if len(self.segments) > 3 {
+		segments = append(segments, self.segments[3:]...)
+	}
+
+	return segments
+}
+
+// Return the pre-release, empty for releases
+func (self TerraformVersion) Prerelease() string {
+	return strings.Join(self.prerelease, ".")
+}
+
+// the lowest version ~> excludes, 2.0 for 1.5 and 1.6.0 for 1.5.0, empty
+// when only the major is given
+func (self TerraformVersion) pessimisticBound() string {
+	if len(self.segments) < 2 {
+		return ""
+	}
+
+	bound := make([]string, len(self.segments)-1)
+	for i := range bound {
+		bound[i] = strconv.FormatUint(self.segments[i], 10)
+	}
+
+	last := len(bound) - 1
+	bound[last] = strconv.FormatUint(self.segments[last]+1, 10)
+
+	return strings.Join(bound, ".")
+}
+
+// Compares against other version
+//
+// Returns 0 if both are equal, 1 if self is bigger and -1 if self is lower
+func (self TerraformVersion) Compare(other TerraformVersion) int {
+	for i := 0; i < len(self.segments) || i < len(other.segments); i++ {
+		var a, b uint64
+		if i < len(self.segments) {
+			a = self.segments[i]
+		}
+		if i < len(other.segments) {
+			b = other.segments[i]
+		}
+
+		if cmp := compareUint(a, b); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return comparePrerelease(self.prerelease, other.prerelease)
+}
+
+// the numbers of the version, without pre-release and build metadata
+func (self TerraformVersion) release() string {
+	segments := self.Segments()
+	numbers := make([]string, len(segments))
+	for i, segment := range segments {
+		numbers[i] = strconv.FormatUint(segment, 10)
+	}
+
+	return strings.Join(numbers, ".")
+}
+
+// Return true if both versions are equal
+func (self TerraformVersion) Equal(other TerraformVersion) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self TerraformVersion) Less(other TerraformVersion) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the version with at least 3 numbers, without v prefix
+func (self TerraformVersion) String() string {
+	version := self.release()
+	if len(self.prerelease) > 0 {
+		version = version + "-" + self.Prerelease()
+	}
+
+	if len(self.build) > 0 {
+		version = version + "+" + strings.Join(self.build, ".")
+	}
+
+	return version
+}
+
+// a pre-release only matches comparisons naming a pre-release of the same
+// numbers, ~> never matching a release when naming a pre-release, checked on
+// the comparisons as written since ~> is desugared
+func admitTerraform(relations []*Constraint) func(string, []*Constraint) bool {
+	return func(version string, constraints []*Constraint) bool {
+		v, err := ParseTerraformVersion(version)
+		if err != nil {
+			return false
+		}
+
+		for _, relation := range relations {
+			if relation.operator == "=" || relation.operator == "!=" {
+				continue
+			}
+
+			bound, err := ParseTerraformVersion(relation.version)
+			if err != nil {
+				return false
+			}
+
+			switch {
+			case len(v.prerelease) > 0 && len(bound.prerelease) == 0:
+				return false
+			case len(v.prerelease) > 0 && !sameSegments(v.Segments(), bound.Segments()):
+				return false
+			case len(v.prerelease) == 0 && len(bound.prerelease) > 0 && relation.operator == "~>":
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// the range admitTerraform lets through, pre-releases being told apart by
+// their numbers once compared, so 1.5.0-beta and 1.5.0.0-beta share a window
+func admittedTerraform(relations []*Constraint) func([]*Constraint) *Range {
+	return func(constraints []*Constraint) *Range {
+		result := newSplitRange(Terraform, []interval{{noBound, noBound}}, []interval{{noBound, noBound}})
+		for _, relation := range relations {
+			if relation.operator == "=" || relation.operator == "!=" {
+				continue
+			}
+
+			bound, err := ParseTerraformVersion(relation.version)
+			if err != nil {
+				return newSplitRange(Terraform, nil, nil)
+			}
+
+			releases := []interval{{noBound, noBound}}
+			if len(bound.prerelease) > 0 && relation.operator == "~>" {
+				releases = nil
+			}
+
+			var prereleases []interval
+			if len(bound.prerelease) > 0 {
+				prereleases = []interval{prereleaseWindow(bound.release())}
+			}
+
+			result = result.Intersect(newSplitRange(Terraform, releases, prereleases))
+		}
+
+		return result
+	}
+}
+
+func sameSegments(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type terraformScheme struct{}
+
+func (terraformScheme) Parse(version string) (fmt.Stringer, error) {
+	v, err := ParseTerraformVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (terraformScheme) Compare(version1, version2 string) int {
+	return CompareTerraform(version1, version2)
+}
+
+func (terraformScheme) compareValues(v1, v2 fmt.Stringer) int {
+	return v1.(TerraformVersion).Compare(v2.(TerraformVersion))
+}
+
+func (terraformScheme) releaseOf(version string) (string, bool) {
+	v, err := ParseTerraformVersion(version)
+
+	return v.release(), err == nil && len(v.prerelease) > 0
+}
+
+func (terraformScheme) ParseConstraint(constraint string) (Matcher, error) {
+	group, err := ParseTerraformConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (terraformScheme) Format(version string) (string, error) {
+	v, err := ParseTerraformVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
@@ -0,0 +1,117 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var terraformValues = map[string]string{
+	"1.5.0":          "1.5.0",
+	"v1.6-beta1":     "1.6.0-beta1",
+	"1":              "1.0.0",
+	"1.2.3.4":        "1.2.3.4",
+	"1.02.3":         "1.2.3",
+	" 1.2.3+meta.1 ": "1.2.3+meta.1",
+	"1.0.0-rc.01":    "1.0.0-rc.01",
+}
+
+func TestParseTerraformVersion(t *testing.T) {
+	for in, out := range terraformValues {
+		v, err := ParseTerraformVersion(in)
+		if err != nil || v.String() != out || v.Original() != in {
+			t.Errorf("FAIL: ParseTerraformVersion(%v) = %v, %v: want %v", in, v, err, out)
+		}
+	}
+
+	for _, in := range []string{"a", "1..2", "1.2-", "1.2.x", "vv1", "1.2+", "-1"} {
+		if v, err := ParseTerraformVersion(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("FAIL: ParseTerraformVersion(%v) = %v, %v: want error %v", in, v, err, ErrInvalidVersion)
+		}
+	}
+}
+
+// as given by hashicorp/go-version
+var compareTerraformValues = map[string]int{
+	"1.5|1.5.0.0":                0,
+	"1.5.0|1.5.1":                -1,
+	"1.10|1.9":                   1,
+	"1.6.0-beta1|1.6.0":          -1,
+	"1.6.0-beta1|1.6.0-beta2":    -1,
+	"1.6.0-beta.2|1.6.0-beta.10": -1,
+	"1.6.0-alpha|1.6.0-1":        1,
+	"1.2.3+a|1.2.3+b":            0,
+	"v1.2.3|1.2.3":               0,
+	"1.2.3.1|1.2.3":              1,
+	"x|1.2.3":                    -1,
+}
+
+func TestCompareTerraform(t *testing.T) {
+	for in, out := range compareTerraformValues {
+		v := strings.Split(in, "|")
+		if x := CompareTerraform(v[0], v[1]); x != out {
+			t.Errorf("FAIL: CompareTerraform(%v) = %v: want %v", in, x, out)
+		}
+
+		if x := CompareTerraform(v[1], v[0]); x != -out {
+			t.Errorf("FAIL: CompareTerraform(%v|%v) = %v: want %v", v[1], v[0], x, -out)
+		}
+
+		if x := Terraform.Compare(v[0], v[1]); x != out {
+			t.Errorf("FAIL: Terraform.Compare(%v) = %v: want %v", in, x, out)
+		}
+	}
+}
+
+var terraformConstraints = map[string]bool{
+	"~> 1.5.0|1.5.0":              true,
+	"~> 1.5.0|1.5.9":              true,
+	"~> 1.5.0|1.6.0":              false,
+	"~> 1.5.0|1.4.9":              false,
+	"~> 1.5|1.9.9":                true,
+	"~> 1.5|2.0.0":                false,
+	"~> 1|9.0.0":                  true,
+	"~> 1|0.9.0":                  false,
+	"~> 1.2.3.4|1.2.3.9":          true,
+	"~> 1.2.3.4|1.2.4":            false,
+	"~> 1.5.0, != 1.5.3|1.5.3":    false,
+	"~> 1.5.0, != 1.5.3|1.5.4":    true,
+	">= 1.0, < 1.4|1.3.9":         true,
+	">= 1.0, < 1.4|1.4.0":         false,
+	"1.2.3|1.2.3":                 true,
+	"= 1.2.3|v1.2.3.0":            true,
+	"!= 1.2.3|1.2.4":              true,
+	"> 1.0|1.1.0-beta":            false,
+	">= 1.1.0-alpha|1.1.0-beta":   true,
+	">= 1.1.0-alpha|1.2.0-beta":   false,
+	">= 1.1.0-alpha|1.1.0":        true,
+	"~> 1.1.0-beta|1.1.0-rc1":     true,
+	"~> 1.1.0-beta|1.1.0":         false,
+	"~> 1.1.0-beta|1.1.1-rc1":     false,
+	"= 1.1.0-beta|1.1.0-beta":     true,
+	"!= 1.1.0|1.1.0-beta":         true,
+	">= 1.0, != 1.1.0|1.1.0-beta": false,
+	"~> 1.0|invalid":              false,
+}
+
+func TestParseTerraformConstraint(t *testing.T) {
+	for in, out := range terraformConstraints {
+		tmp := strings.Split(in, "|")
+
+		c, err := ParseTerraformConstraint(tmp[0])
+		if err != nil {
+			t.Errorf("FAIL: ParseTerraformConstraint(%v) error = %v", tmp[0], err)
+			continue
+		}
+
+		if x := c.Match(tmp[1]); x != out {
+			t.Errorf("FAIL: Match(%v) = %v: want %v", in, x, out)
+		}
+	}
+
+	for in, out := range map[string]error{"": ErrEmptyConstraint, "~ 1.0": ErrInvalidConstraint, "=> 1.0": ErrInvalidConstraint, "~> 1.x": ErrInvalidVersion} {
+		if c, err := Terraform.ParseConstraint(in); c != nil || !errors.Is(err, out) {
+			t.Errorf("FAIL: Terraform.ParseConstraint(%v) = %v, %v: want error %v", in, c, err, out)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind of versioning scheme a parsed version follows
+type Kind int
+
+const (
+	// Version not recognized by Normalize, kept as given
+	KindUnknown Kind = iota
+	// Classical dotted versioning, like 1.2.3
+	KindClassic
+	// Date-based versioning, like 2010-01-02
+	KindDate
+	// Development branch, like dev-master or 1.x-dev
+	KindBranch
+)
+
+var regexpNormalizedModifier = regexp.MustCompile(`^(.*?)(?:-(alpha|beta|RC|patch)(\d*))?(-dev)?$`)
+
+// Version is a version string parsed once, ready to be compared many times
+type Version struct {
+	original        string
+	normalized      string
+	kind            Kind
+	segments        []int
+	stability       int
+	stabilityNumber int
+	dev             bool
+	build           string
+	numbers         []int
+}
+
+// Parses a version string into a Version
+//
+// The string is normalized with Normalize, so anything Normalize accepts is
+// accepted here; versions Normalize does not recognize are kept as given and
+// reported as KindUnknown. Only empty strings are rejected.
+//
+// Usage
+//     v, _ := version.Parse("1.0.2-b3")
+//     v.Segments()
+//     Returns: []int{1, 0, 2, 0}
+//
+//     v.Stability() == version.Beta
+//     Returns: true
+func Parse(version string) (Version, error) {
+	if strings.TrimSpace(version) == "" {
+		return Version{}, ErrEmptyVersion
+	}
+
+	normalized, kind := normalize(version)
+
+	return newVersion(version, normalized, kind)
+}
+
+func newVersion(original, normalized string, kind Kind) (Version, error) {
+	v := Version{
+		original:   original,
+		normalized: normalized,
+		kind:       kind,
+		stability:  Stable,
+		numbers:    prepNumbers(normalized),
+	}
+
+	release := normalized
+	if kind == KindClassic || kind == KindDate {
+		release, v.build = splitBuild(normalized)
+	}
+
+	switch kind {
+	case KindBranch:
+		v.dev = true
+		v.stability = Development
+
+		// named branches like dev-feature have no numeric segments
+		v.segments, _ = atoiSegments(strings.Split(strings.TrimSuffix(normalized, "-dev"), "."))
+	case KindClassic, KindDate:
+		result := regexpNormalizedModifier.FindStringSubmatch(release)
+
+		separator := "."
+		if kind == KindDate {
+			separator = "-"
+		}
+
+		segments, err := atoiSegments(strings.Split(result[1], separator))
+		if err != nil {
+			return Version{}, &ParseError{original, ErrInvalidVersion, "segment out of range"}
+		}
+		v.segments = segments
+
+		switch result[2] {
+		case "alpha":
+			v.stability = Alpha
+		case "beta":
+			v.stability = Beta
+		case "RC":
+			v.stability = RC
+		}
+
+		if result[3] != "" {
+			v.stabilityNumber, _ = strconv.Atoi(result[3])
+		}
+
+		if result[4] != "" {
+			v.dev = true
+			if result[2] == "" {
+				v.stability = Development
+			}
+		}
+	}
+
+	return v, nil
+}
+
+func atoiSegments(values []string) ([]int, error) {
+	segments := make([]int, len(values))
+	for i, value := range values {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = number
+	}
+
+	return segments, nil
+}
+
+// Parses a version string into a Version, failing on anything that is not a
+// valid Composer-style version
+//
+// Unlike Parse, versions Normalize would return unchanged are rejected with a
+// *ParseError telling why.
+//
+// Usage
+//     _, err := version.ParseStrict("1.0-foo")
+//     errors.Is(err, version.ErrUnknownModifier)
+//     Returns: true
+func ParseStrict(version string) (Version, error) {
+	normalized, kind, err := normalizeStrict(version)
+	if err != nil {
+		return Version{}, err
+	}
+
+	return newVersion(version, normalized, kind)
+}
+
+// Return the string given to Parse
+func (self Version) Original() string {
+	return self.original
+}
+
+// Return the numeric segments, the four dotted numbers of a classical version
+// or the date parts of a date-based one
+func (self Version) Segments() []int {
+	return append([]int(nil), self.segments...)
+}
+
+// Return the stability, one of Development, Alpha, Beta, RC or Stable
+func (self Version) Stability() int {
+	return self.stability
+}
+
+// Return the number following the stability modifier, 5 for 1.0-beta5
+func (self Version) StabilityNumber() int {
+	return self.stabilityNumber
+}
+
+// Return true for development versions and branches
+func (self Version) IsDev() bool {
+	return self.dev
+}
+
+// Return the build metadata, exp.sha.5114f85 for 1.0.0+exp.sha.5114f85, empty
+// when there is none
+func (self Version) Build() string {
+	return self.build
+}
+
+// Return the kind of versioning, classical, date-based or branch
+func (self Version) Kind() Kind {
+	return self.kind
+}
+
+// Compares against other version
+//
+// Same rules than CompareSimple, returns 0 if both are equal, 1 if self is
+// bigger and -1 if self is lower
+func (self Version) Compare(other Version) int {
+	return compareNumbers(self.numbers, other.numbers)
+}
+
+// Compares against other version like Compare, using build metadata as a
+// tie-breaker like CompareWithBuild
+func (self Version) CompareWithBuild(other Version) int {
+	if cmp := self.Compare(other); cmp != 0 {
+		return cmp
+	}
+
+	return compareBuild(self.build, other.build)
+}
+
+// Return true if both versions are equal
+func (self Version) Equal(other Version) bool {
+	return self.Compare(other) == 0
+}
+
+// Return true if self is lower than other
+func (self Version) Less(other Version) bool {
+	return self.Compare(other) < 0
+}
+
+// Return the normalized version string, build metadata included
+func (self Version) String() string {
+	return self.normalized
+}
@@ -0,0 +1,129 @@
+package version
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type parsedVersion struct {
+	normalized      string
+	kind            Kind
+	segments        []int
+	stability       int
+	stabilityNumber int
+	dev             bool
+}
+
+var parseValues = map[string]parsedVersion{
+	"1.0.2":           {"1.0.2.0", KindClassic, []int{1, 0, 2, 0}, Stable, 0, false},
+	"v1.0":            {"1.0.0.0", KindClassic, []int{1, 0, 0, 0}, Stable, 0, false},
+	"10.4.13-b5":      {"10.4.13.0-beta5", KindClassic, []int{10, 4, 13, 0}, Beta, 5, false},
+	"1.0.0-rC15-dev":  {"1.0.0.0-RC15-dev", KindClassic, []int{1, 0, 0, 0}, RC, 15, true},
+	"1.0-alpha":       {"1.0.0.0-alpha", KindClassic, []int{1, 0, 0, 0}, Alpha, 0, false},
+	"1.0.0.pl3":       {"1.0.0.0-patch3", KindClassic, []int{1, 0, 0, 0}, Stable, 3, false},
+	"1.0-dev":         {"1.0.0.0-dev", KindClassic, []int{1, 0, 0, 0}, Development, 0, true},
+	"2010-01-02":      {"2010-01-02", KindDate, []int{2010, 1, 2}, Stable, 0, false},
+	"20100102-203040": {"20100102-203040", KindDate, []int{20100102, 203040}, Stable, 0, false},
+	"2010.01.02-p1":   {"2010-01-02-patch1", KindDate, []int{2010, 1, 2}, Stable, 1, false},
+	"dev-master":      {"9999999-dev", KindBranch, []int{9999999}, Development, 0, true},
+	"1.x-dev":         {"1.9999999.9999999.9999999-dev", KindBranch, []int{1, 9999999, 9999999, 9999999}, Development, 0, true},
+	"dev-feature-foo": {"dev-feature-foo", KindBranch, nil, Development, 0, true},
+	"banana":          {"banana", KindUnknown, nil, Stable, 0, false},
+}
+
+func TestParse(t *testing.T) {
+	for in, out := range parseValues {
+		v, err := Parse(in)
+		if err != nil {
+			t.Errorf("FAIL: Parse(%v) returned error %v", in, err)
+			continue
+		}
+
+		x := parsedVersion{v.String(), v.Kind(), v.Segments(), v.Stability(), v.StabilityNumber(), v.IsDev()}
+		if !reflect.DeepEqual(x, out) {
+			t.Errorf("FAIL: Parse(%v) = %+v: want %+v", in, x, out)
+		}
+
+		if v.Original() != in {
+			t.Errorf("FAIL: Parse(%v).Original() = %v: want %v", in, v.Original(), in)
+		}
+	}
+}
+
+var buildValues = map[string]string{
+	"1.0.0":                 "",
+	"1.0.0+20130313":        "20130313",
+	"1.0.0-rc1+exp.sha.5f8": "exp.sha.5f8",
+	"2010-01-02+build.1":    "build.1",
+	"dev-master":            "",
+}
+
+func TestVersionBuild(t *testing.T) {
+	for in, out := range buildValues {
+		v, _ := Parse(in)
+		if x := v.Build(); x != out {
+			t.Errorf("FAIL: Parse(%v).Build() = %v: want %v", in, x, out)
+		}
+	}
+
+	v1, _ := Parse("1.0.0-rc1+exp.sha.5f8")
+	if v1.Stability() != RC || v1.StabilityNumber() != 1 {
+		t.Errorf("FAIL: Parse(%v) stability = %v%v: want %v%v", v1, v1.Stability(), v1.StabilityNumber(), RC, 1)
+	}
+
+	v2, _ := Parse("1.0.0-rc1+exp.sha.5f9")
+	if v1.Compare(v2) != 0 || v1.CompareWithBuild(v2) != -1 {
+		t.Errorf("FAIL: Parse(%v) = %v, %v: want 0, -1", v1, v1.Compare(v2), v1.CompareWithBuild(v2))
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for _, in := range []string{"", "  "} {
+		if _, err := Parse(in); err != ErrEmptyVersion {
+			t.Errorf("FAIL: Parse(%q) error = %v: want %v", in, err, ErrEmptyVersion)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	for in := range compareVersionSimpleValues {
+		v := strings.Split(in, "|")
+		v1, _ := Parse(v[0])
+		v2, _ := Parse(v[1])
+
+		want := CompareSimple(Normalize(v[0]), Normalize(v[1]))
+		if x := v1.Compare(v2); x != want {
+			t.Errorf("FAIL: Parse(%v).Compare(%v) = %v: want %v", v[0], v[1], x, want)
+		}
+
+		if x := v1.Equal(v2); x != (want == 0) {
+			t.Errorf("FAIL: Parse(%v).Equal(%v) = %v: want %v", v[0], v[1], x, want == 0)
+		}
+
+		if x := v1.Less(v2); x != (want < 0) {
+			t.Errorf("FAIL: Parse(%v).Less(%v) = %v: want %v", v[0], v[1], x, want < 0)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for in, out := range parseValues {
+		v, err := ParseStrict(in)
+		if out.kind == KindUnknown {
+			if err == nil {
+				t.Errorf("FAIL: ParseStrict(%v) = %v: want error", in, v)
+			}
+			continue
+		}
+
+		if err != nil || v.String() != out.normalized || v.Kind() != out.kind {
+			t.Errorf("FAIL: ParseStrict(%v) = %v, %v: want %v", in, v, err, out.normalized)
+		}
+	}
+
+	if _, err := ParseStrict("1.0-foo"); !errors.Is(err, ErrUnknownModifier) {
+		t.Errorf("FAIL: ParseStrict(1.0-foo) error = %v: want %v", err, ErrUnknownModifier)
+	}
+}